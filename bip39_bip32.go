@@ -0,0 +1,200 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains BIP-0032 master key generation and derivation for bip39 package.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+//
+// Types
+//
+
+// Extended private key version type, i.e. the 4-byte prefix identifying the network
+type XprvVersion uint32
+
+//
+// Constants
+//
+const (
+	// Extended private key versions
+	XprvMainnet XprvVersion = 0x0488ade4
+	XprvTestnet XprvVersion = 0x04358394
+
+
+	// HMAC key for master key generation
+	masterKeyHmacKey = "Bitcoin seed"
+	// Private key length in bytes
+	privateKeyLen = 32
+	// First hardened index
+	hardenedIndex = 0x80000000
+	// Serialized extended key length in bytes
+	extendedKeyLen = 78
+)
+
+//
+// Variables
+//
+var (
+	// ErrMasterKey is returned when the master key generated from a seed is not valid (extremely unlikely)
+	ErrMasterKey = errors.New("The generated master key is not valid")
+	// ErrChildKey is returned when a derived child key is not valid (extremely unlikely)
+	ErrChildKey = errors.New("The derived child key is not valid")
+	// ErrXprvVersion is returned when trying to serialize an extended private key with an unknown version
+	ErrXprvVersion = errors.New("The specified extended private key version is not valid")
+
+	// Order of the secp256k1 curve
+	secp256k1N, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+)
+
+//
+// Exported functions
+//
+
+// Generate the BIP-0032 master key from a mnemonic using the specified passphrase for seed generation.
+// The master private key and the chain code (32-byte each) are returned.
+func (mnemonic *Mnemonic) GenerateMasterKey(passphrase string) ([]byte, []byte, error) {
+	// Generate seed
+	seed, err := mnemonic.GenerateSeed(passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer Wipe(seed)
+
+	// Generate master key from seed
+	return masterKeyFromSeed(seed)
+}
+
+// Generate the BIP-0032 master extended private key from a mnemonic using the specified passphrase for seed generation.
+// The master key is serialized with the specified version (XprvMainnet for "xprv...", XprvTestnet for "tprv...") and Base58Check-encoded,
+// so that it can be imported into other wallets and tools.
+// Error is returned if the version or the mnemonic is not valid.
+func (mnemonic *Mnemonic) GenerateXprv(passphrase string, network XprvVersion) (string, error) {
+	// Validate version
+	if network != XprvMainnet && network != XprvTestnet {
+		return "", fmt.Errorf("version 0x%08x: %w", uint32(network), ErrXprvVersion)
+	}
+	// Generate master key
+	privKey, chainCode, err := mnemonic.GenerateMasterKey(passphrase)
+	if err != nil {
+		return "", err
+	}
+	defer Wipe(privKey)
+	defer Wipe(chainCode)
+
+	return serializeXprv(privKey, chainCode, network), nil
+}
+
+//
+// Not-exported functions
+//
+
+// Serialize the specified master private key and chain code as Base58Check extended private key with the specified version.
+// Depth, parent fingerprint and child number are zero, since it's the master key.
+func serializeXprv(privKey []byte, chainCode []byte, version XprvVersion) string {
+	// Data is: version (4) || depth (1) || parent fingerprint (4) || child number (4) || chain code (32) || 0x00 || private key (32)
+	data := make([]byte, 0, extendedKeyLen)
+	data = append(data, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(data, uint32(version))
+	data = append(data, make([]byte, 1 + 4 + 4)...)
+	data = append(data, chainCode...)
+	data = append(data, 0)
+	data = append(data, privKey...)
+	defer Wipe(data)
+
+	return base58CheckEncode(data)
+}
+
+// Generate the BIP-0032 master key and chain code from the specified seed.
+func masterKeyFromSeed(seed []byte) ([]byte, []byte, error) {
+	// Compute HMAC-SHA512
+	h := hmac.New(sha512.New, []byte(masterKeyHmacKey))
+	h.Write(seed)
+	hash := h.Sum(nil)
+
+	// Left part is the private key, right part is the chain code
+	privKey, chainCode := hash[:privateKeyLen], hash[privateKeyLen:]
+	// Private key shall be in the range [1, n - 1]
+	if !isValidPrivateKey(privKey) {
+		Wipe(hash)
+		return nil, nil, ErrMasterKey
+	}
+
+	return privKey, chainCode, nil
+}
+
+// Derive the hardened child private key and chain code with the specified index (hardened bit excluded).
+func deriveHardenedChild(privKey []byte, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	// Data is: 0x00 || private key || index
+	data := make([]byte, 0, 1 + privateKeyLen + 4)
+	data = append(data, 0)
+	data = append(data, privKey...)
+	data = append(data, make([]byte, 4)...)
+	binary.BigEndian.PutUint32(data[1 + privateKeyLen:], index | hardenedIndex)
+	defer Wipe(data)
+
+	// Compute HMAC-SHA512 using the chain code as key
+	h := hmac.New(sha512.New, chainCode)
+	h.Write(data)
+	hash := h.Sum(nil)
+	defer Wipe(hash)
+
+	// Left part shall be a valid private key
+	if !isValidPrivateKey(hash[:privateKeyLen]) {
+		return nil, nil, ErrChildKey
+	}
+
+	// Child key is computed as (left part + parent key) mod n
+	keyInt := new(big.Int).SetBytes(hash[:privateKeyLen])
+	keyInt.Add(keyInt, new(big.Int).SetBytes(privKey))
+	keyInt.Mod(keyInt, secp256k1N)
+	if keyInt.Sign() == 0 {
+		return nil, nil, ErrChildKey
+	}
+
+	// Serialize child key to 32-byte
+	childKey := make([]byte, privateKeyLen)
+	keyBytes := keyInt.Bytes()
+	copy(childKey[privateKeyLen - len(keyBytes):], keyBytes)
+
+	childChainCode := make([]byte, len(hash) - privateKeyLen)
+	copy(childChainCode, hash[privateKeyLen:])
+
+	return childKey, childChainCode, nil
+}
+
+// Get if the specified private key is valid, i.e. in the range [1, n - 1].
+func isValidPrivateKey(privKey []byte) bool {
+	keyInt := new(big.Int).SetBytes(privKey)
+	return keyInt.Sign() != 0 && keyInt.Cmp(secp256k1N) < 0
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+//
+// Types
+//
+
+// Master key test vector entry structure
+type testVectMasterKeyEntry struct {
+	Mnemonic  string
+	MasterKey string
+	ChainCode string
+	Xprv      string
+	Tprv      string
+}
+
+//
+// Variables
+//
+
+// Tests for master key generation from mnemonic (with testPassphrase)
+var testVectMasterKey = []testVectMasterKeyEntry {
+	testVectMasterKeyEntry {
+		Mnemonic:  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		MasterKey: "cbedc75b0d6412c85c79bc13875112ef912fd1e756631b5a00330866f22ff184",
+		ChainCode: "a3fa8c983223306de0f0f65e74ebb1e98aba751633bf91d5fb56529aa5c132c1",
+		Xprv:      "xprv9s21ZrQH143K3h3fDYiay8mocZ3afhfULfb5GX8kCBdno77K4HiA15Tg23wpbeF1pLfs1c5SPmYHrEpTuuRhxMwvKDwqdKiGJS9XFKzUsAF",
+		Tprv:      "tprv8ZgxMBicQKsPeWHBt7a68nPnvgTnuDhUgDWC8wZCgA8GahrQ3f3uWpq7wE7Uc1dLBnCe1hhCZ886K6ND37memRDWqsA9HgSKDXtwh2Qxo6J",
+	},
+	testVectMasterKeyEntry {
+		Mnemonic:  "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		MasterKey: "dddda5cdef032caf0b966bb1c7d2a8836e827aaa6480e9067080a075656d3228",
+		ChainCode: "3dff8e4e898ecd7f09dd62023bd6ca129312216b427d4f6b650f456da06b543f",
+		Xprv:      "xprv9s21ZrQH143K2gA81bYFHqU68xz1cX2APaSq5tt6MFSLeXnCKV1RVUJt9FWNTbrrryem4ZckN8k4Ls1H6nwdvDTvnV7zEXs2HgPezuVccsq",
+		Tprv:      "tprv8ZgxMBicQKsPdVPegAPkTV65T6QDr34Aj8MwxKJYqDvpS8XHJrMB1DgL4Rg2TyFBERBY4fEWXVKroiZ2E1HajGjXK8LHttb5Cn95Sb7WxK3",
+	},
+	testVectMasterKeyEntry {
+		Mnemonic:  "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+		MasterKey: "40c1cf7c7d5fcd6a4b1f8460efb62a47c3680e4c378d70e6ffa5b5baa310efac",
+		ChainCode: "2cd568d37c19eb04f6c8a06eb32c3058ab1dc8709d411afd259d6a82c967c395",
+		Xprv:      "xprv9s21ZrQH143K2WFF16X85T2QCpndrGwx6GueB72Zf3AHwHJaknRXNF37ZmDrtHrrLSHvbuRejXcnYxoZKvRquTPyp2JiNG3XcjQyzSEgqCB",
+		Tprv:      "tprv8ZgxMBicQKsPdKUmffNdF6ePWxCr5nyxRppm3XT291emit3fk9mGszQZUwPWtfFAhsphc13QttCb1pMJT8mniWfaLfX22cmaXqAQSAHuzTy",
+	},
+}
+
+//
+// Functions
+//
+
+// Test master key generation
+func TestMasterKey(t *testing.T) {
+	for _, testEntry := range testVectMasterKey {
+		mnemonic := MnemonicFromString(testEntry.Mnemonic)
+
+		// Generate master key
+		masterKey, chainCode, err := mnemonic.GenerateMasterKey(testPassphrase)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' master key generation returned error: %s", testEntry.Mnemonic, err.Error())
+			continue
+		}
+
+		masterKeyHex := hex.EncodeToString(masterKey)
+		chainCodeHex := hex.EncodeToString(chainCode)
+		if masterKeyHex != testEntry.MasterKey {
+			t.Errorf("Mnemonic '%s' master key was incorrect: expected %s, got: %s", testEntry.Mnemonic, testEntry.MasterKey, masterKeyHex)
+		}
+		if chainCodeHex != testEntry.ChainCode {
+			t.Errorf("Mnemonic '%s' chain code was incorrect: expected %s, got: %s", testEntry.Mnemonic, testEntry.ChainCode, chainCodeHex)
+		}
+	}
+
+	// BIP-0032 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	masterKey, chainCode, _ := masterKeyFromSeed(seed)
+	if hex.EncodeToString(masterKey) != "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35" ||
+	   hex.EncodeToString(chainCode) != "873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508" {
+		t.Errorf("Master key from seed was incorrect: got %x, %x", masterKey, chainCode)
+	}
+}
+
+// Test extended private key generation
+func TestXprv(t *testing.T) {
+	for _, testEntry := range testVectMasterKey {
+		mnemonic := MnemonicFromString(testEntry.Mnemonic)
+
+		xprv, err := mnemonic.GenerateXprv(testPassphrase, XprvMainnet)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' xprv generation returned error: %s", testEntry.Mnemonic, err.Error())
+		} else if xprv != testEntry.Xprv {
+			t.Errorf("Mnemonic '%s' xprv was incorrect: expected %s, got: %s", testEntry.Mnemonic, testEntry.Xprv, xprv)
+		}
+
+		tprv, err := mnemonic.GenerateXprv(testPassphrase, XprvTestnet)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' tprv generation returned error: %s", testEntry.Mnemonic, err.Error())
+		} else if tprv != testEntry.Tprv {
+			t.Errorf("Mnemonic '%s' tprv was incorrect: expected %s, got: %s", testEntry.Mnemonic, testEntry.Tprv, tprv)
+		}
+	}
+
+	// BIP-0032 test vector 1
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	masterKey, chainCode, _ := masterKeyFromSeed(seed)
+	if xprv := serializeXprv(masterKey, chainCode, XprvMainnet); xprv != "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi" {
+		t.Errorf("Xprv from seed was incorrect: got %s", xprv)
+	}
+
+	// Invalid version
+	if _, err := MnemonicFromString(testVectMasterKey[0].Mnemonic).GenerateXprv(testPassphrase, XprvVersion(0)); !errors.Is(err, ErrXprvVersion) {
+		t.Errorf("Xprv generation with invalid version returned wrong error (%v)", err)
+	}
+	// Invalid mnemonic
+	for _, testEntry := range testVectMnemonicInvalid {
+		if xprv, err := MnemonicFromString(testEntry.Mnemonic).GenerateXprv(testPassphrase, XprvMainnet); xprv != "" || !errors.Is(err, testEntry.Err) {
+			t.Errorf("Xprv generation from invalid mnemonic (%s) returned wrong error (%v)", testEntry.Mnemonic, err)
+		}
+	}
+}
+
+// Test Base58Check encoding
+func TestBase58CheckEncode(t *testing.T) {
+	testData := map[string]string {
+		"":       "3QJmnh",
+		"00":     "1Wh4bh",
+		"0000ff": "11VmypLhv",
+		"61":     "C2dGTwc",
+		// Bitcoin address (version byte and public key hash)
+		"00010966776006953d5567439e5e39f86a0d273bee": "16UwLL9Risc3QfPqBUvKofHmBQ7wMtjvM",
+	}
+	for testHex, expEncoded := range testData {
+		data, _ := hex.DecodeString(testHex)
+		if encoded := base58CheckEncode(data); encoded != expEncoded {
+			t.Errorf("Base58Check encoding of %s was incorrect: expected %s, got: %s", testHex, expEncoded, encoded)
+		}
+	}
+}
+
+// Test master key generation from invalid mnemonic
+func TestMasterKeyInvalidMnemonic(t *testing.T) {
+	for _, testEntry := range testVectMnemonicInvalid {
+		mnemonic := MnemonicFromString(testEntry.Mnemonic)
+
+		// Master key shall be nil and error shall be not nil
+		masterKey, chainCode, err := mnemonic.GenerateMasterKey(testPassphrase)
+		if masterKey != nil || chainCode != nil {
+			t.Errorf("Master key from invalid mnemonic (%s) was not nil", testEntry.Mnemonic)
+		}
+		if !errors.Is(err, testEntry.Err) {
+			t.Errorf("Master key from invalid mnemonic (%s) returned wrong error (%v)", testEntry.Mnemonic, err)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains BIP-0085 mnemonic derivation for bip39 package.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+)
+
+//
+// Constants
+//
+const (
+	// BIP-0085 purpose
+	bip85Purpose = 83696968
+	// BIP-0085 application number for BIP-0039
+	bip85AppBip39 = 39
+	// HMAC key for entropy derivation
+	bip85HmacKey = "bip-entropy-from-k"
+)
+
+//
+// Variables
+//
+var (
+	// ErrDerivationIndex is returned when trying to derive using an index that is not valid for hardened derivation
+	ErrDerivationIndex = errors.New("The specified index is not valid for hardened derivation")
+)
+
+//
+// Exported functions
+//
+
+// Derive a child mnemonic from a mnemonic as specified by BIP-0085 (BIP-0039 application).
+// The master key is generated from the mnemonic using the specified passphrase, then the path
+// m/83696968'/39'/{language}'/{words}'/{index}' is derived and its key is used for computing the child entropy.
+// The index shall be lower than 2^31.
+func (mnemonic *Mnemonic) DeriveBIP85Mnemonic(passphrase string, language Language, words int, index uint32) (*Mnemonic, error) {
+	// Validate parameters
+	err := validateWordsNum(words)
+	if err != nil {
+		return nil, err
+	}
+	_, err = getWordsList(language)
+	if err != nil {
+		return nil, err
+	}
+	if index >= hardenedIndex {
+		return nil, fmt.Errorf("index %d: %w", index, ErrDerivationIndex)
+	}
+
+	// Generate master key
+	privKey, chainCode, err := mnemonic.GenerateMasterKey(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer Wipe(privKey)
+	defer Wipe(chainCode)
+
+	// Derive entropy
+	entropy, err := bip85DeriveEntropy(privKey, chainCode, []uint32 { bip85Purpose, bip85AppBip39, uint32(language), uint32(words), index },
+	                                   wordsNumToEntropyBitLen(words) / 8)
+	if err != nil {
+		return nil, err
+	}
+	defer Wipe(entropy)
+
+	// Generate mnemonic from entropy
+	return MnemonicFromEntropyLang(entropy, language)
+}
+
+//
+// Not-exported functions
+//
+
+// Derive entropy of the specified length from the master key using the specified path (hardened indexes).
+// The master key and chain code are not modified, the keys and chain codes of the derived levels are wiped.
+func bip85DeriveEntropy(privKey []byte, chainCode []byte, path []uint32, entropyLen int) ([]byte, error) {
+	// Derive path, wiping each level once the next one is derived (except the master one, owned by the caller)
+	currKey, currChainCode := privKey, chainCode
+	for i, index := range path {
+		childKey, childChainCode, err := deriveHardenedChild(currKey, currChainCode, index)
+		if i > 0 {
+			Wipe(currKey)
+			Wipe(currChainCode)
+		}
+		if err != nil {
+			return nil, err
+		}
+		currKey, currChainCode = childKey, childChainCode
+	}
+	if len(path) > 0 {
+		defer Wipe(currKey)
+		defer Wipe(currChainCode)
+	}
+
+	// Compute HMAC-SHA512 of the derived key
+	h := hmac.New(sha512.New, []byte(bip85HmacKey))
+	h.Write(currKey)
+	hash := h.Sum(nil)
+	defer Wipe(hash)
+
+	// Truncate to the entropy length
+	entropy := make([]byte, entropyLen)
+	copy(entropy, hash)
+
+	return entropy, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+//
+// Types
+//
+
+// BIP-0085 test vector entry structure
+type testVectBip85Entry struct {
+	Words    int
+	Index    uint32
+	Entropy  string
+	Mnemonic string
+}
+
+//
+// Constants
+//
+const (
+	// Master key and chain code of the BIP-0085 test vectors, from the xprv:
+	// xprv9s21ZrQH143K2LBWUUQRFXhucrQqBpKdRRxNVq2zBqsx8HVqFk2uYo8kmbaLLHRdqtQpUm98uKfu3vca1LqdGhUtyoFnCNkfmXRyPXLjbKb
+	testBip85MasterKey = "3f15e5d852dc2e9ba5e9fe189a8dd2e1547badef5b563bbe6579fc6807d80ed9"
+	testBip85ChainCode = "1b67969d1ec69bdfeeae43213da8460ba34b92d0788c8f7bfcfa44906e8a589c"
+	// Mnemonic for testing derivation from a mnemonic (with testPassphrase)
+	testBip85Mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+)
+
+//
+// Variables
+//
+
+// Tests from BIP-0085 page (English language):
+// https://github.com/bitcoin/bips/blob/master/bip-0085.mediawiki
+var testVectBip85 = []testVectBip85Entry {
+	testVectBip85Entry {
+		Words:    12,
+		Index:    0,
+		Entropy:  "6250b68daf746d12a24d58b4787a714b",
+		Mnemonic: "girl mad pet galaxy egg matter matrix prison refuse sense ordinary nose",
+	},
+	testVectBip85Entry {
+		Words:    18,
+		Index:    0,
+		Entropy:  "938033ed8b12698449d4bbca3c853c66b293ea1b1ce9d9dc",
+		Mnemonic: "near account window bike charge season chef number sketch tomorrow excuse sniff circle vital hockey outdoor supply token",
+	},
+	testVectBip85Entry {
+		Words:    24,
+		Index:    0,
+		Entropy:  "ae131e2312cdc61331542efe0d1077bac5ea803adf24b313a4f0e48e9c51f37f",
+		Mnemonic: "puppy ocean match cereal symbol another shed magic wrap hammer bulb intact gadget divorce twin tonight reason outdoor destroy simple truth cigar social volcano",
+	},
+}
+
+// Tests for BIP-0085 derivation from testBip85Mnemonic (English language)
+var testVectBip85Mnemonic = []testVectBip85Entry {
+	testVectBip85Entry {
+		Words:   12,
+		Index:   0,
+		Entropy: "2b1d7c4f311137fa95f6302e64cdb885",
+	},
+	testVectBip85Entry {
+		Words:   12,
+		Index:   1,
+		Entropy: "c41a6eceeeeb439a4b40658bf1d12d29",
+	},
+	testVectBip85Entry {
+		Words:   24,
+		Index:   0,
+		Entropy: "677e8a759749557236064811bcb476cbb572db24986cb7136f37d3f97fb9bf05",
+	},
+}
+
+//
+// Functions
+//
+
+// Test BIP-0085 vector
+func TestBip85Vector(t *testing.T) {
+	privKey, _ := hex.DecodeString(testBip85MasterKey)
+	chainCode, _ := hex.DecodeString(testBip85ChainCode)
+
+	for _, testEntry := range testVectBip85 {
+		path := []uint32 { bip85Purpose, bip85AppBip39, uint32(LangEnglish), uint32(testEntry.Words), testEntry.Index }
+
+		// Derive entropy
+		entropy, err := bip85DeriveEntropy(privKey, chainCode, path, wordsNumToEntropyBitLen(testEntry.Words) / 8)
+		if err != nil {
+			t.Errorf("BIP-0085 entropy derivation (%d words) returned error: %s", testEntry.Words, err.Error())
+			continue
+		}
+		entropyHex := hex.EncodeToString(entropy)
+		if entropyHex != testEntry.Entropy {
+			t.Errorf("BIP-0085 entropy derivation was incorrect: expected %s, got: %s", testEntry.Entropy, entropyHex)
+		}
+
+		// Get mnemonic
+		mnemonic, _ := MnemonicFromEntropy(entropy)
+		if mnemonic.Words != testEntry.Mnemonic {
+			t.Errorf("BIP-0085 mnemonic was incorrect: expected %s, got: %s", testEntry.Mnemonic, mnemonic.Words)
+		}
+	}
+
+	// Master key and chain code shall not be wiped, since they are owned by the caller
+	if hex.EncodeToString(privKey) != testBip85MasterKey || hex.EncodeToString(chainCode) != testBip85ChainCode {
+		t.Errorf("BIP-0085 master key or chain code was modified by the derivation")
+	}
+}
+
+// Test BIP-0085 derivation from mnemonic
+func TestBip85Mnemonic(t *testing.T) {
+	mnemonic := MnemonicFromString(testBip85Mnemonic)
+
+	for _, testEntry := range testVectBip85Mnemonic {
+		// Derive mnemonic
+		childMnemonic, err := mnemonic.DeriveBIP85Mnemonic(testPassphrase, LangEnglish, testEntry.Words, testEntry.Index)
+		if err != nil {
+			t.Errorf("BIP-0085 mnemonic derivation (%d words, index %d) returned error: %s", testEntry.Words, testEntry.Index, err.Error())
+			continue
+		}
+
+		// Check entropy
+		entropy, _ := childMnemonic.ToEntropy()
+		entropyHex := hex.EncodeToString(entropy)
+		if entropyHex != testEntry.Entropy {
+			t.Errorf("BIP-0085 mnemonic derivation was incorrect: expected entropy %s, got: %s", testEntry.Entropy, entropyHex)
+		}
+	}
+}
+
+// Test BIP-0085 derivation with invalid parameters
+func TestBip85Invalid(t *testing.T) {
+	mnemonic := MnemonicFromString(testBip85Mnemonic)
+
+	// Invalid words number
+	_, err := mnemonic.DeriveBIP85Mnemonic(testPassphrase, LangEnglish, 13, 0)
+	if !errors.Is(err, ErrWordsNum) {
+		t.Errorf("BIP-0085 derivation with invalid words number returned wrong error (%v)", err)
+	}
+	// Invalid language
+	_, err = mnemonic.DeriveBIP85Mnemonic(testPassphrase, Language(-1), WordsNum12, 0)
+	if !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("BIP-0085 derivation with invalid language returned wrong error (%v)", err)
+	}
+	// Invalid index
+	_, err = mnemonic.DeriveBIP85Mnemonic(testPassphrase, LangEnglish, WordsNum12, hardenedIndex)
+	if !errors.Is(err, ErrDerivationIndex) {
+		t.Errorf("BIP-0085 derivation with invalid index returned wrong error (%v)", err)
+	}
+	// Invalid mnemonic
+	_, err = MnemonicFromString(testVectMnemonicInvalid[1].Mnemonic).DeriveBIP85Mnemonic(testPassphrase, LangEnglish, WordsNum12, 0)
+	if !errors.Is(err, ErrChecksum) {
+		t.Errorf("BIP-0085 derivation from invalid mnemonic returned wrong error (%v)", err)
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains the loading of custom words lists for bip39 package.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+//
+// Variables
+//
+var (
+	// ErrDuplicateWord is returned when loading or using a custom words list containing the same word more than once
+	ErrDuplicateWord = errors.New("The words list contains a duplicate word")
+)
+
+//
+// Exported functions
+//
+
+// Load a custom words list from the specified reader, containing one word per line.
+// Leading and trailing whitespaces of each line are removed and empty lines are skipped.
+// The words list shall contain exactly 2048 different words, so that it can be used with MnemonicFromEntropyCustom and ToEntropyCustom.
+func LoadWordList(r io.Reader) ([]string, error) {
+	wordsList := make([]string, 0, wordsListLen)
+	wordsLineMap := make(map[string]int, wordsListLen)
+
+	// Read words line by line
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" {
+			continue
+		}
+		if prevLineNum, ok := wordsLineMap[word]; ok {
+			return nil, fmt.Errorf("word %q at lines %d and %d: %w", word, prevLineNum, lineNum, ErrDuplicateWord)
+		}
+
+		wordsLineMap[word] = lineNum
+		wordsList = append(wordsList, word)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Validate words list length
+	err := validateWordsListLen(wordsList)
+	if err != nil {
+		return nil, err
+	}
+
+	return wordsList, nil
+}
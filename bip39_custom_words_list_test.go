@@ -0,0 +1,83 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+//
+// Functions
+//
+
+// Test words list loading
+func TestLoadWordList(t *testing.T) {
+	// One word per line
+	wordsList, err := LoadWordList(strings.NewReader(strings.Join(wordsListEn, "\n")))
+	if err != nil {
+		t.Errorf("Words list loading returned error: %s", err.Error())
+	} else if !reflect.DeepEqual(wordsList, wordsListEn) {
+		t.Errorf("Words list loading was incorrect")
+	}
+
+	// Whitespaces, empty lines and CRLF line endings
+	wordsList, err = LoadWordList(strings.NewReader("\n  " + strings.Join(wordsListEn, " \r\n\n\t") + "\r\n"))
+	if err != nil {
+		t.Errorf("Words list loading with whitespaces returned error: %s", err.Error())
+	} else if !reflect.DeepEqual(wordsList, wordsListEn) {
+		t.Errorf("Words list loading with whitespaces was incorrect")
+	}
+
+	// Loaded words list shall be usable as custom one
+	mnemonic, err := MnemonicFromEntropyCustom(make([]byte, 16), wordsList)
+	if err != nil || mnemonic.Words != testVect[0].Mnemonic {
+		t.Errorf("Mnemonic from loaded words list was incorrect (%v)", err)
+	}
+}
+
+// Test invalid words list loading
+func TestLoadWordListInvalid(t *testing.T) {
+	// Wrong words number
+	for _, testWordsList := range [][]string { nil, wordsListEn[1:], append(append([]string {}, wordsListEn...), "notexistent") } {
+		if _, err := LoadWordList(strings.NewReader(strings.Join(testWordsList, "\n"))); !errors.Is(err, ErrInvalidWordlistLen) {
+			t.Errorf("Words list loading with %d words returned wrong error (%v)", len(testWordsList), err)
+		}
+	}
+
+	// Duplicate word
+	wordsList := append(append([]string {}, wordsListEn[:len(wordsListEn) - 1]...), "abandon")
+	_, err := LoadWordList(strings.NewReader(strings.Join(wordsList, "\n")))
+	if !errors.Is(err, ErrDuplicateWord) || !strings.Contains(err.Error(), "lines 1 and 2048") {
+		t.Errorf("Words list loading with duplicate word returned wrong error (%v)", err)
+	}
+
+	// Reader error
+	if _, err := LoadWordList(iotest.TimeoutReader(strings.NewReader(strings.Join(wordsListEn, "\n")))); !errors.Is(err, iotest.ErrTimeout) {
+		t.Errorf("Words list loading with reader error returned wrong error (%v)", err)
+	}
+}
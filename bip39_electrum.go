@@ -0,0 +1,133 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains the Electrum (v2) seed validation and generation for bip39 package.
+// Electrum mnemonics are not BIP-0039 mnemonics: their validity is given by a version prefix
+// instead of a checksum, and the seed is generated with a different salt.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"unicode"
+)
+
+//
+// Constants
+//
+const (
+	// HMAC key for computing the seed version
+	electrumVersionHmacKey = "Seed version"
+	// Modifier for seed salt
+	electrumSeedSaltMod = "electrum"
+)
+
+//
+// Variables
+//
+var (
+	// ErrElectrumVersion is returned when trying to generate the seed from a mnemonic that is not a valid Electrum one
+	ErrElectrumVersion = errors.New("The mnemonic is not a valid Electrum mnemonic")
+
+	// Valid Electrum version prefixes (standard, segwit, 2FA, 2FA segwit)
+	electrumVersionPrefixes = []string {
+		"01",
+		"100",
+		"101",
+		"102",
+	}
+)
+
+//
+// Exported functions
+//
+
+// Get if the specified words are a valid Electrum (v2) mnemonic.
+// The words are normalized as Electrum does, then the mnemonic is valid if the HMAC-SHA512 of it begins with a known version prefix.
+// Note that the words are not checked against any words list, since Electrum doesn't require it.
+func ElectrumMnemonicIsValid(words string) bool {
+	// Compute version
+	mac := hmac.New(sha512.New, []byte(electrumVersionHmacKey))
+	mac.Write([]byte(electrumNormalize(words)))
+	version := hex.EncodeToString(mac.Sum(nil))
+
+	// Check prefix
+	for _, prefix := range electrumVersionPrefixes {
+		if strings.HasPrefix(version, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Generate the seed from the specified Electrum (v2) mnemonic words using the specified passphrase for protection.
+// Error is returned if the mnemonic is not a valid Electrum mnemonic.
+func ElectrumGenerateSeed(words string, passphrase string) ([]byte, error) {
+	// Validate mnemonic
+	if !ElectrumMnemonicIsValid(words) {
+		return nil, ErrElectrumVersion
+	}
+
+	// Generate seed from words and passphrase normalized as Electrum does
+	// (NFKD normalization in pbkdf2Seed leaves them unchanged, since they are already NFKD-normalized)
+	return pbkdf2Seed(electrumNormalize(words), electrumNormalize(passphrase), electrumSeedSaltMod, seedPbkdf2Round), nil
+}
+
+//
+// Not-exported functions
+//
+
+// Normalize the specified string as Electrum does.
+// The string is NFKD-normalized and converted to lowercase, accents (i.e. combining characters) are removed,
+// whitespaces are collapsed into single spaces and the ones between CJK characters are removed.
+func electrumNormalize(str string) string {
+	str = strings.ToLower(normalizeNfkd(str))
+
+	// Remove combining characters
+	str = removeCombiningChars(str)
+
+	// Collapse whitespaces
+	runes := []rune(collapseWhitespaces(str))
+
+	// Remove whitespaces between CJK characters
+	var strBuff strings.Builder
+	for i, r := range runes {
+		if r == ' ' && i > 0 && i < len(runes) - 1 && isCjk(runes[i - 1]) && isCjk(runes[i + 1]) {
+			continue
+		}
+		strBuff.WriteRune(r)
+	}
+
+	return strBuff.String()
+}
+
+// Get if the specified character is a CJK one.
+func isCjk(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+//
+// Types
+//
+
+// Electrum test vector entry structure
+type testVectElectrumEntry struct {
+	Mnemonic   string
+	Passphrase string
+	Seed       string
+}
+
+//
+// Variables
+//
+
+// Tests for Electrum seed generation (from Electrum test suite)
+var testVectElectrum = []testVectElectrumEntry {
+	testVectElectrumEntry {
+		Mnemonic:   "wild father tree among universe such mobile favorite target dynamic credit identify",
+		Passphrase: "",
+		Seed:       "aac2a6302e48577ab4b46f23dbae0774e2e62c796f797d0a1b5faeb528301e3064342dafb79069e7c4c6b8c38ae11d7a973bec0d4f70626f8cc5184a8d0b0756",
+	},
+	testVectElectrumEntry {
+		Mnemonic:   "wild father tree among universe such mobile favorite target dynamic credit identify",
+		Passphrase: "Did you ever hear the tragedy of Darth Plagueis the Wise?",
+		Seed:       "4aa29f2aeb0127efb55138ab9e7be83b36750358751906f86c662b21a1ea1370f949e6d1a12fa56d3d93cadda93038c76ac8118597364e46f5156fde6183c82f",
+	},
+	// Uppercase letters and multiple whitespaces
+	testVectElectrumEntry {
+		Mnemonic:   " Wild Father  tree among universe such mobile favorite target dynamic credit identify ",
+		Passphrase: "",
+		Seed:       "aac2a6302e48577ab4b46f23dbae0774e2e62c796f797d0a1b5faeb528301e3064342dafb79069e7c4c6b8c38ae11d7a973bec0d4f70626f8cc5184a8d0b0756",
+	},
+	// Whitespaces between CJK characters are removed
+	testVectElectrumEntry {
+		Mnemonic:   "眼 悲 叛 改 节 跃 衡 响 疆 股 遂 冬",
+		Passphrase: "",
+		Seed:       "0b9077db7b5a50dbb6f61821e2d35e255068a5847e221138048a20e12d80b673ce306b6fe7ac174ebc6751e11b7037be6ee9f17db8040bb44f8466d519ce2abf",
+	},
+	// Accents are removed
+	testVectElectrumEntry {
+		Mnemonic:   "almíbar tibio superar vencer hacha peatón príncipe matar consejo polen vehículo odisea",
+		Passphrase: "",
+		Seed:       "18bffd573a960cc775bbd80ed60b7dc00bc8796a186edebe7fc7cf1f316da0fe937852a969c5c79ded8255cdf54409537a16339fbe33fb9161af793ea47faa7a",
+	},
+	testVectElectrumEntry {
+		Mnemonic:   "almibar tibio superar vencer hacha peaton principe matar consejo polen vehiculo odisea",
+		Passphrase: "",
+		Seed:       "18bffd573a960cc775bbd80ed60b7dc00bc8796a186edebe7fc7cf1f316da0fe937852a969c5c79ded8255cdf54409537a16339fbe33fb9161af793ea47faa7a",
+	},
+}
+
+// Tests for invalid Electrum mnemonics
+var testVectElectrumInvalid = []string {
+	"",
+	"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	"science dawn member doll dutch real can sponsor tent curtain genre cruise",
+}
+
+//
+// Functions
+//
+
+// Test Electrum seed generation
+func TestElectrumVector(t *testing.T) {
+	for _, currTest := range testVectElectrum {
+		if !ElectrumMnemonicIsValid(currTest.Mnemonic) {
+			t.Errorf("Electrum mnemonic '%s' is not valid", currTest.Mnemonic)
+			continue
+		}
+
+		seed, err := ElectrumGenerateSeed(currTest.Mnemonic, currTest.Passphrase)
+		if err != nil {
+			t.Errorf("Electrum mnemonic '%s' seed generation returned error: %s", currTest.Mnemonic, err.Error())
+			continue
+		}
+		seedHex := hex.EncodeToString(seed)
+		if seedHex != currTest.Seed {
+			t.Errorf("Electrum seed was incorrect: expected %s, got: %s", currTest.Seed, seedHex)
+		}
+	}
+}
+
+// Test invalid Electrum mnemonics
+func TestElectrumInvalid(t *testing.T) {
+	for _, testStr := range testVectElectrumInvalid {
+		if ElectrumMnemonicIsValid(testStr) {
+			t.Errorf("Invalid Electrum mnemonic '%s' is valid", testStr)
+		}
+
+		// Seed shall be nil and error shall be not nil
+		seed, err := ElectrumGenerateSeed(testStr, "")
+		if seed != nil {
+			t.Errorf("Seed from invalid Electrum mnemonic (%s) was not nil", testStr)
+		}
+		if !errors.Is(err, ErrElectrumVersion) {
+			t.Errorf("Seed from invalid Electrum mnemonic (%s) returned wrong error (%v)", testStr, err)
+		}
+	}
+}
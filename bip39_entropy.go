@@ -28,10 +28,30 @@ package bip39
 // Imports
 //
 import (
+	"context"
 	"crypto/rand"
+	"crypto/sha512"
 	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
 )
 
+//
+// Types
+//
+
+// Entropy type
+// Being a slice of bytes, it can be used wherever a []byte is expected (e.g. MnemonicFromEntropy).
+type Entropy []byte
+
+// Entropy strength type, i.e. entropy bit length
+type Strength int
+
 //
 // Constants
 //
@@ -42,6 +62,19 @@ const (
 	EntropyBits192 = 192
 	EntropyBits224 = 224
 	EntropyBits256 = 256
+
+	// Entropy strengths (12, 18 and 24 words respectively)
+	StrengthStandard Strength = EntropyBits128
+	StrengthMedium   Strength = EntropyBits192
+	StrengthHigh     Strength = EntropyBits256
+
+	// Maximum byte length of the buffers kept by the batch pool, so that large batches don't stay alive
+	entropyBatchPoolMaxLen = 100 * (EntropyBits256 / 8)
+
+	// Number of dice faces
+	diceFacesNum = 6
+	// Additional bits carried by the minimum number of dice rolls, so that the modulo bias is at most 2^-64
+	diceRollsBiasBits = 64
 )
 
 //
@@ -50,6 +83,12 @@ const (
 var (
 	// ErrEntropyBitLen is returned when trying to generate entropy with invalid bit length
 	ErrEntropyBitLen = errors.New("The specified bit length is not valid for entropy generation")
+	// ErrDiceRolls is returned when trying to get entropy from dice rolls containing invalid characters
+	ErrDiceRolls = errors.New("The dice rolls shall only contain digits from 1 to 6")
+	// ErrNotEnoughDiceRolls is returned when trying to get entropy from too few dice rolls for the bit length
+	ErrNotEnoughDiceRolls = errors.New("The dice rolls are not enough for the specified bit length")
+	// ErrEntropyPartsLen is returned when trying to combine entropy parts with different lengths
+	ErrEntropyPartsLen = errors.New("The entropy parts shall have the same length")
 
 	// Helper map for checking bit length validity
 	entropyBitLenMap = map[int]bool {
@@ -59,6 +98,15 @@ var (
 		EntropyBits224 : true,
 		EntropyBits256 : true,
 	}
+
+	// Source of random bytes, crypto/rand by default
+	randReader io.Reader = rand.Reader
+
+	// Pools of entropy buffers, keyed by byte length, for reducing allocations when generating many mnemonics
+	// The buffers are always wiped before being put back, so no secret is shared between callers
+	entropyPools = newEntropyPools()
+	// Pool of entropy buffers with any other length (e.g. entropy of multiple mnemonics), up to entropyBatchPoolMaxLen
+	entropyBatchPool sync.Pool
 )
 
 //
@@ -66,27 +114,365 @@ var (
 //
 
 // Generate entropy bytes with the specified bit length.
-func GenerateEntropy(bitLen int) ([]byte, error) {
+// The bit length shall be one of the EntropyBits* constants, prefer GenerateEntropyStrength if unsure.
+// The entropy buffer is taken from an internal pool, it can be returned with PutEntropy when no longer needed.
+func GenerateEntropy(bitLen int) (Entropy, error) {
+	// Validate bit length
+	err := validateEntropyBitLen(bitLen)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate random entropy into a pooled buffer
+	buff := getEntropyBuffer(bitLen / 8)
+	err = readRandom(*buff)
+	if err != nil {
+		putEntropyBuffer(buff)
+		return nil, err
+	}
+	return *buff, nil
+}
+
+// Generate entropy bytes with the specified bit length, reading them from the specified reader (e.g. a hardware TRNG).
+// The reader is read until the exact number of bytes is got, so readers returning fewer bytes per call are supported.
+// io.ErrUnexpectedEOF is returned if the reader ends before (also if no byte is read), any other read error is returned as it is.
+func GenerateEntropyFromReaderStrict(r io.Reader, bitLen int) ([]byte, error) {
+	// Validate bit length
+	err := validateEntropyBitLen(bitLen)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read entropy
+	entropy := make([]byte, bitLen / 8)
+	_, err = io.ReadFull(r, entropy)
+	if err != nil {
+		Wipe(entropy)
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return entropy, nil
+}
+
+// Return entropy bytes to the internal pool, so that they can be reused by the next GenerateEntropy call.
+// The entropy is wiped before being returned, so no secret is leaked to the next caller.
+// After calling it, the entropy shall not be used anymore. Entropy whose length is not valid for a mnemonic is only wiped.
+func PutEntropy(entropy []byte) {
+	Wipe(entropy)
+
+	// Only pool whole buffers with a valid length, so that the memory after the slice length is never reused
+	pool, ok := entropyPools[len(entropy)]
+	if ok && cap(entropy) == len(entropy) {
+		pool.Put(&entropy)
+	}
+}
+
+// Generate entropy bytes with the specified bit length, like GenerateEntropy.
+// The context is checked before reading the random bytes, so that ctx.Err() is returned if it's already done (e.g. on shutdown).
+func GenerateEntropyContext(ctx context.Context, bitLen int) (Entropy, error) {
+	// Validate bit length
+	err := validateEntropyBitLen(bitLen)
+	if err != nil {
+		return nil, err
+	}
+	// Check context
+	err = ctx.Err()
+	if err != nil {
+		return nil, err
+	}
+
+	return GenerateEntropy(bitLen)
+}
+
+// Generate entropy bytes with the specified strength (e.g. StrengthStandard).
+// This is the recommended way for generating entropy, since the strength constants are self-documenting and always valid.
+func GenerateEntropyStrength(strength Strength) (Entropy, error) {
+	return GenerateEntropy(int(strength))
+}
+
+// Get entropy bytes with the specified bit length from a string of dice rolls (digits from 1 to 6, e.g. "16254...").
+// Each roll is mapped to a base-6 digit (1 -> 0, ..., 6 -> 5) and the resulting number, most significant roll first,
+// is reduced modulo 2^bitLen. Since a power of 6 is not a power of 2, the reduction is biased: for this reason, at least
+// DiceRollsNum(bitLen) rolls are required, so that the rolls carry 64 bits more than the bit length and the bias is negligible
+// (statistical distance from uniform at most 2^-64). More rolls are accepted. Whitespaces are ignored.
+// ErrDiceRolls is returned, with the index of the offending roll (whitespaces excluded), if a roll is not valid.
+func EntropyFromDiceRolls(rolls string, bitLen int) ([]byte, error) {
+	// Validate bit length
+	err := validateEntropyBitLen(bitLen)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert rolls to number
+	rolls = strings.Join(strings.Fields(rolls), "")
+	val := new(big.Int)
+	base := big.NewInt(diceFacesNum)
+	rollsNum := 0
+	for _, roll := range rolls {
+		if roll < '1' || roll > '0' + diceFacesNum {
+			return nil, fmt.Errorf("dice roll %q at index %d: %w", roll, rollsNum, ErrDiceRolls)
+		}
+		val.Mul(val, base)
+		val.Add(val, big.NewInt(int64(roll - '1')))
+		rollsNum++
+	}
+	// Validate rolls number
+	minRollsNum := DiceRollsNum(bitLen)
+	if rollsNum < minRollsNum {
+		return nil, fmt.Errorf("dice rolls %d (minimum %d): %w", rollsNum, minRollsNum, ErrNotEnoughDiceRolls)
+	}
+
+	// Reduce to bit length and convert to bytes
+	mask := new(big.Int).Lsh(big.NewInt(1), uint(bitLen))
+	val.Mod(val, mask)
+	entropy := make([]byte, bitLen / 8)
+	valBytes := val.Bytes()
+	copy(entropy[len(entropy) - len(valBytes):], valBytes)
+	Wipe(valBytes)
+
+	return entropy, nil
+}
+
+// Get the dice rolls (digits from 1 to 6) corresponding to the specified entropy, i.e. the inverse of EntropyFromDiceRolls.
+// The rolls are padded to DiceRollsNum of the entropy bit length.
+// Error is returned if the entropy length is not valid.
+func DiceRollsFromEntropy(entropy []byte) (string, error) {
+	// Validate entropy length
+	err := validateEntropyBitLen(len(entropy) * 8)
+	if err != nil {
+		return "", err
+	}
+
+	// Convert entropy to base-6 digits, least significant first
+	val := new(big.Int).SetBytes(entropy)
+	base := big.NewInt(diceFacesNum)
+	digit := new(big.Int)
+	rollsNum := DiceRollsNum(len(entropy) * 8)
+	rolls := make([]byte, rollsNum)
+	for i := rollsNum - 1; i >= 0; i-- {
+		val.DivMod(val, base, digit)
+		rolls[i] = byte('1' + digit.Int64())
+	}
+
+	return string(rolls), nil
+}
+
+// Get the minimum number of dice rolls for getting entropy with the specified bit length.
+// The rolls carry 64 bits more than the bit length, so that the modulo bias of EntropyFromDiceRolls is negligible.
+func DiceRollsNum(bitLen int) int {
+	return int(math.Ceil(float64(bitLen + diceRollsBiasBits) / math.Log2(diceFacesNum)))
+}
+
+// Get entropy bytes with the specified bit length from a string of coin flips ('0' and '1' characters, one per bit).
+// The string length shall be exactly the bit length, otherwise ErrBinaryString is returned (as for invalid characters).
+func EntropyFromCoinFlips(flips string, bitLen int) ([]byte, error) {
 	// Validate bit length
 	err := validateEntropyBitLen(bitLen)
 	if err != nil {
 		return nil, err
 	}
+	// Validate flips length
+	if len(flips) != bitLen {
+		return nil, fmt.Errorf("coin flips %d (bit length %d): %w", len(flips), bitLen, ErrBinaryString)
+	}
+
+	return binaryStringToBytes(flips)
+}
+
+// Get entropy bytes with the specified bit length by hashing the whole content of the specified reader (e.g. a photo) with SHA-512.
+// The hash is truncated to the bit length. If mix is true, the hash is also XORed with secure random bytes (see SetRandReader).
+// WARNING: file content is NOT high entropy (e.g. a photo can be found, copied or guessed), so without mixing the entropy is only
+// as secret as the file. It's meant for convenience and experiments, mix it with secure random bytes for anything else.
+// Error is returned if the bit length is not valid or the reader fails.
+func EntropyFromReaderHashed(r io.Reader, bitLen int, mix bool) ([]byte, error) {
+	// Validate bit length
+	err := validateEntropyBitLen(bitLen)
+	if err != nil {
+		return nil, err
+	}
+
+	// Hash reader content
+	h := sha512.New()
+	_, err = io.Copy(h, r)
+	if err != nil {
+		return nil, err
+	}
+	hash := h.Sum(nil)
+	defer Wipe(hash)
 
-	// Generate random entropy
+	// Truncate to bit length
 	entropy := make([]byte, bitLen / 8)
-	_, err = rand.Read(entropy)
-	return entropy, err
+	copy(entropy, hash)
+
+	// Mix with random bytes if required
+	if mix {
+		randBytes := make([]byte, len(entropy))
+		defer Wipe(randBytes)
+		err = readRandom(randBytes)
+		if err != nil {
+			Wipe(entropy)
+			return nil, err
+		}
+		for i := range entropy {
+			entropy[i] ^= randBytes[i]
+		}
+	}
+
+	return entropy, nil
+}
+
+// Split entropy bytes into two parts, so that the entropy can be backed up in two separate places (e.g. paper wallets).
+// The first part is a random pad and the second one is the entropy XORed with the pad, so each part alone reveals nothing about the entropy.
+// Both parts have the same length of the entropy, so each of them can be encoded as a mnemonic. Use CombineEntropyXOR for getting the entropy back.
+// It's a simple 2-of-2 scheme: if any part is lost, the entropy cannot be recovered.
+// Error is returned if the entropy bit length is not valid.
+func SplitEntropyXOR(entropy []byte) (partA []byte, partB []byte, err error) {
+	// Validate entropy bit length
+	err = validateEntropyBitLen(len(entropy) * 8)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Generate random pad
+	partA = make([]byte, len(entropy))
+	err = readRandom(partA)
+	if err != nil {
+		return nil, nil, err
+	}
+	// XOR entropy with pad
+	partB = make([]byte, len(entropy))
+	for i := range entropy {
+		partB[i] = entropy[i] ^ partA[i]
+	}
+
+	return partA, partB, nil
+}
+
+// Combine the entropy parts got by SplitEntropyXOR, getting back the original entropy bytes.
+// Error is returned if the parts have different lengths or their bit length is not valid.
+func CombineEntropyXOR(partA []byte, partB []byte) ([]byte, error) {
+	// Validate parts length
+	if len(partA) != len(partB) {
+		return nil, fmt.Errorf("entropy parts lengths %d and %d: %w", len(partA), len(partB), ErrEntropyPartsLen)
+	}
+	err := validateEntropyBitLen(len(partA) * 8)
+	if err != nil {
+		return nil, err
+	}
+
+	// XOR parts
+	entropy := make([]byte, len(partA))
+	for i := range partA {
+		entropy[i] = partA[i] ^ partB[i]
+	}
+
+	return entropy, nil
+}
+
+// Set the source of random bytes used by the whole package (e.g. GenerateEntropy, MnemonicFromWordsNum, SplitEntropy), crypto/rand if nil.
+// It's meant for tests that need reproducible entropy: override it at your own risk, since a weak source leads to weak mnemonics.
+// It's not safe for concurrent use, so it shall be called before any generation (e.g. at the beginning of a test, resetting it at the end).
+func SetRandReader(r io.Reader) {
+	if r == nil {
+		r = rand.Reader
+	}
+	randReader = r
+}
+
+// Get if the entropy byte length is valid for generating a mnemonic (i.e. 16, 20, 24, 28 or 32 bytes).
+// It allows checking entropy got from user input (e.g. pasted hex) before calling MnemonicFromEntropy.
+func IsValidEntropyLen(entropy []byte) bool {
+	return Entropy(entropy).IsValid()
+}
+
+// Get the valid entropy byte lengths, sorted in ascending order.
+// A new slice is returned at each call, so it can be freely modified.
+func ValidEntropyByteLens() []int {
+	byteLens := make([]int, 0, len(entropyBitLenMap))
+	for bitLen := range entropyBitLenMap {
+		byteLens = append(byteLens, bitLen / 8)
+	}
+	sort.Ints(byteLens)
+	return byteLens
+}
+
+// Get the bit length of the entropy.
+func (entropy Entropy) BitLen() int {
+	return len(entropy) * 8
+}
+
+// Get if the entropy bit length is valid for generating a mnemonic.
+func (entropy Entropy) IsValid() bool {
+	return validateEntropyBitLen(entropy.BitLen()) == nil
+}
+
+// Wipe the entropy by overwriting it with zeros.
+func (entropy Entropy) Wipe() {
+	Wipe(entropy)
 }
 
 //
 // Not-exported functions
 //
 
+// Fill the specified slice with random bytes from the package source of random bytes.
+func readRandom(slice []byte) error {
+	_, err := io.ReadFull(randReader, slice)
+	return err
+}
+
+// Create the entropy pools, one for each valid entropy byte length.
+func newEntropyPools() map[int]*sync.Pool {
+	pools := make(map[int]*sync.Pool, len(entropyBitLenMap))
+	for bitLen := range entropyBitLenMap {
+		pools[bitLen / 8] = &sync.Pool {}
+	}
+	return pools
+}
+
+// Get an entropy buffer with the specified byte length from the pools.
+// The buffer content is always zero, since buffers are wiped before being put back.
+func getEntropyBuffer(byteLen int) *[]byte {
+	pool, ok := entropyPools[byteLen]
+	if !ok {
+		pool = &entropyBatchPool
+	}
+
+	buff, ok := pool.Get().(*[]byte)
+	if !ok {
+		buff = new([]byte)
+	}
+	if cap(*buff) < byteLen {
+		*buff = make([]byte, byteLen)
+	}
+	*buff = (*buff)[:byteLen]
+	return buff
+}
+
+// Wipe the specified entropy buffer and put it back to the pools.
+// The buffer shall have been got by getEntropyBuffer and it shall not be used anymore.
+// Buffers larger than entropyBatchPoolMaxLen are only wiped, so that they can be garbage collected.
+func putEntropyBuffer(buff *[]byte) {
+	Wipe((*buff)[:cap(*buff)])
+
+	pool, ok := entropyPools[len(*buff)]
+	if !ok {
+		if cap(*buff) > entropyBatchPoolMaxLen {
+			return
+		}
+		pool = &entropyBatchPool
+	}
+	pool.Put(buff)
+}
+
 // Validate the specified bit length.
 func validateEntropyBitLen(bitLen int) error {
 	if !entropyBitLenMap[bitLen] {
-		return ErrEntropyBitLen
+		return fmt.Errorf("entropy bit length got %d, expected %d/%d/%d/%d/%d: %w", bitLen,
+		                  EntropyBits128, EntropyBits160, EntropyBits192, EntropyBits224, EntropyBits256, ErrEntropyBitLen)
 	}
 	return nil
 }
@@ -0,0 +1,91 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains the structured error types for bip39 package.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"fmt"
+)
+
+//
+// Types
+//
+
+// Error returned when a mnemonic contains an invalid word, i.e. a word not found in the words list.
+// It can be got by errors.As for knowing the word position, while errors.Is(err, ErrInvalidWord) keeps working since it unwraps to ErrInvalidWord.
+type WordError struct {
+	// Word position in the mnemonic, starting from zero
+	Index int
+	// Invalid word
+	Word string
+}
+
+// Error returned when the checksum of a mnemonic is not valid.
+// It can be got by errors.As for knowing the checksums, while errors.Is(err, ErrChecksum) keeps working since it unwraps to ErrChecksum.
+// For BIP-0039 mnemonics the checksums are binary strings (e.g. "0101"), for Monero mnemonics they are the checksum words.
+type ChecksumError struct {
+	// Checksum got from the mnemonic
+	Got string
+	// Checksum computed from the entropy
+	Want string
+}
+
+//
+// Exported functions
+//
+
+// Get the error message.
+func (err *WordError) Error() string {
+	return fmt.Sprintf("invalid word %q at position %d: %s", err.Word, err.Index, ErrInvalidWord)
+}
+
+// Get the wrapped error, i.e. ErrInvalidWord.
+func (err *WordError) Unwrap() error {
+	return ErrInvalidWord
+}
+
+// Get the error message.
+func (err *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum got %s, expected %s: %s", err.Got, err.Want, ErrChecksum)
+}
+
+// Get the wrapped error, i.e. ErrChecksum.
+func (err *ChecksumError) Unwrap() error {
+	return ErrChecksum
+}
+
+//
+// Not-exported functions
+//
+
+// Create a checksum error from the specified checksum bits, which are in the least significant bits of the bytes.
+func newChecksumError(got byte, want byte, bitLen int) *ChecksumError {
+	return &ChecksumError {
+		Got:  fmt.Sprintf("%0*b", bitLen, got),
+		Want: fmt.Sprintf("%0*b", bitLen, want),
+	}
+}
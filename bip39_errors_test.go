@@ -0,0 +1,118 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"errors"
+	"testing"
+)
+
+//
+// Functions
+//
+
+// Test word error
+func TestWordError(t *testing.T) {
+	testMnemonic := "legal winner thank year wave sausage worth useful legal winnr thank yellow"
+
+	// Validation
+	var wordErr *WordError
+	err := MnemonicFromString(testMnemonic).Validate()
+	if !errors.As(err, &wordErr) {
+		t.Errorf("Mnemonic '%s' validation did not return a word error (%v)", testMnemonic, err)
+	} else if wordErr.Index != 9 || wordErr.Word != "winnr" {
+		t.Errorf("Mnemonic '%s' word error was incorrect: expected winnr at 9, got: %s at %d", testMnemonic, wordErr.Word, wordErr.Index)
+	}
+	// Sentinel shall still match
+	if !errors.Is(err, ErrInvalidWord) {
+		t.Errorf("Mnemonic '%s' word error did not wrap the invalid word error (%v)", testMnemonic, err)
+	}
+	if err.Error() != `invalid word "winnr" at position 9: ` + ErrInvalidWord.Error() {
+		t.Errorf("Mnemonic '%s' word error message was incorrect: got: %s", testMnemonic, err.Error())
+	}
+
+	// Entropy into buffer
+	wordErr = nil
+	_, err = MnemonicFromString(testMnemonic).ToEntropyInto(make([]byte, EntropyBits256 / 8))
+	if !errors.As(err, &wordErr) || wordErr.Index != 9 || wordErr.Word != "winnr" {
+		t.Errorf("Mnemonic '%s' entropy into buffer word error was incorrect (%v)", testMnemonic, err)
+	}
+	// Prefix words
+	wordErr = nil
+	_, err = MnemonicFromWordsNumWithPrefix(WordsNum12, []string { "legal", "winnr" }, LangEnglish)
+	if !errors.As(err, &wordErr) || wordErr.Index != 1 || wordErr.Word != "winnr" {
+		t.Errorf("Mnemonic with prefix word error was incorrect (%v)", err)
+	}
+	// Monero mnemonic
+	wordErr = nil
+	setTestMoneroWordsList(t, testMoneroWordsList(), 3)
+	_, err = MoneroMnemonicToSeed(&Mnemonic { Words: testVectMoneroInvalid[1].Mnemonic })
+	SetMoneroWordsList(nil)
+	if !errors.As(err, &wordErr) || wordErr.Index != 23 || wordErr.Word != "zzzx" {
+		t.Errorf("Monero mnemonic word error was incorrect (%v)", err)
+	}
+}
+
+// Test checksum error
+func TestChecksumError(t *testing.T) {
+	// The last word (you, 11111111001) has the same entropy bits of the valid one (yellow, 11111111000) but checksum 1001 instead of 1000
+	testMnemonic := "legal winner thank year wave sausage worth useful legal winner thank you"
+
+	// Validation
+	var chksumErr *ChecksumError
+	err := MnemonicFromString(testMnemonic).Validate()
+	if !errors.As(err, &chksumErr) {
+		t.Errorf("Mnemonic '%s' validation did not return a checksum error (%v)", testMnemonic, err)
+	} else if chksumErr.Got != "1001" || chksumErr.Want != "1000" {
+		t.Errorf("Mnemonic '%s' checksum error was incorrect: expected 1001/1000, got: %s/%s", testMnemonic, chksumErr.Got, chksumErr.Want)
+	}
+	// Sentinel shall still match
+	if !errors.Is(err, ErrChecksum) {
+		t.Errorf("Mnemonic '%s' checksum error did not wrap the checksum error (%v)", testMnemonic, err)
+	}
+	if err.Error() != "checksum got 1001, expected 1000: " + ErrChecksum.Error() {
+		t.Errorf("Mnemonic '%s' checksum error message was incorrect: got: %s", testMnemonic, err.Error())
+	}
+
+	// Entropy into buffer
+	chksumErr = nil
+	_, err = MnemonicFromString(testMnemonic).ToEntropyInto(make([]byte, EntropyBits256 / 8))
+	if !errors.As(err, &chksumErr) || chksumErr.Got != "1001" || chksumErr.Want != "1000" {
+		t.Errorf("Mnemonic '%s' entropy into buffer checksum error was incorrect (%v)", testMnemonic, err)
+	}
+	// Word indexes
+	chksumErr = nil
+	_, err = IndicesToEntropy([]int { 1019, 2015, 1790, 2039, 1983, 1533, 2031, 1919, 1019, 2015, 1790, 2041 })
+	if !errors.As(err, &chksumErr) || chksumErr.Got != "1001" || chksumErr.Want != "1000" {
+		t.Errorf("Word indexes checksum error was incorrect (%v)", err)
+	}
+	// Monero mnemonic, checksums are words
+	chksumErr = nil
+	setTestMoneroWordsList(t, testMoneroWordsList(), 3)
+	_, err = MoneroMnemonicToSeed(&Mnemonic { Words: testVectMoneroInvalid[3].Mnemonic })
+	SetMoneroWordsList(nil)
+	if !errors.As(err, &chksumErr) || chksumErr.Got != "adgx" || chksumErr.Want == chksumErr.Got {
+		t.Errorf("Monero mnemonic checksum error was incorrect (%v)", err)
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build go1.18
+// +build go1.18
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"testing"
+)
+
+//
+// Functions
+//
+
+// Fuzz mnemonic generation from entropy and back
+func FuzzMnemonicRoundTrip(f *testing.F) {
+	for _, bitLen := range testVectEntropyBitLenValid {
+		f.Add(bytes.Repeat([]byte { 0x5a }, bitLen / 8))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// Truncate to the longest valid entropy length
+		entropyLen := 0
+		for _, bitLen := range testVectEntropyBitLenValid {
+			if bitLen / 8 <= len(data) {
+				entropyLen = bitLen / 8
+			}
+		}
+		if entropyLen == 0 {
+			t.Skip()
+		}
+		entropy := data[:entropyLen]
+
+		// Generate mnemonic
+		mnemonic, err := MnemonicFromEntropy(entropy)
+		if err != nil {
+			t.Fatalf("Mnemonic from entropy %x returned error: %s", entropy, err.Error())
+		}
+		// Validate it
+		if err = mnemonic.Validate(); err != nil {
+			t.Fatalf("Mnemonic '%s' validation returned error: %s", mnemonic.Words, err.Error())
+		}
+		// Get entropy back
+		outEntropy, err := mnemonic.ToEntropy()
+		if err != nil {
+			t.Fatalf("Mnemonic '%s' to entropy returned error: %s", mnemonic.Words, err.Error())
+		}
+		if !bytes.Equal(outEntropy, entropy) {
+			t.Fatalf("Mnemonic '%s' entropy was incorrect: expected %x, got: %x", mnemonic.Words, entropy, outEntropy)
+		}
+	})
+}
+
+// Fuzz mnemonic string parsing, which shall never panic
+func FuzzMnemonicFromString(f *testing.F) {
+	for _, currTest := range testVect {
+		f.Add(currTest.Mnemonic)
+	}
+	for _, currTest := range testVectMnemonicInvalid {
+		f.Add(currTest.Mnemonic)
+	}
+
+	f.Fuzz(func(t *testing.T, str string) {
+		mnemonic := MnemonicFromStringLang(str, LangEnglish)
+		entropy, err := mnemonic.ToEntropy()
+		if err != nil {
+			return
+		}
+
+		// A valid mnemonic shall be generated back from its entropy
+		outMnemonic, err := MnemonicFromEntropy(entropy)
+		if err != nil {
+			t.Fatalf("Mnemonic from entropy %x returned error: %s", entropy, err.Error())
+		}
+		if !outMnemonic.Equal(mnemonic) {
+			t.Fatalf("Mnemonic from entropy was incorrect: expected %s, got: %s", mnemonic.Words, outMnemonic.Words)
+		}
+	})
+}
+
+// Fuzz binary string conversion, which shall never panic
+func FuzzBinaryStringToBytes(f *testing.F) {
+	for _, testBinStr := range testBinaryStringInvalid {
+		f.Add(testBinStr)
+	}
+	f.Add("0000000111111110")
+
+	f.Fuzz(func(t *testing.T, binStr string) {
+		slice, err := binaryStringToBytes(binStr)
+		if err != nil {
+			return
+		}
+
+		// A valid binary string shall be converted back to itself
+		if outBinStr := bytesToBinaryString(slice); outBinStr != binStr {
+			t.Fatalf("Binary string conversion was incorrect: expected %s, got: %s", binStr, outBinStr)
+		}
+	})
+}
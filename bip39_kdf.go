@@ -0,0 +1,190 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains the seed key derivation functions for bip39 package.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+//
+// Types
+//
+
+// Interface for a key derivation function that generates the seed from the mnemonic words and the passphrase.
+// The mnemonic words are passed already validated and joined by single spaces.
+type SeedKDF interface {
+	DeriveSeed(mnemonic string, passphrase string) ([]byte, error)
+}
+
+// BIP-0039 key derivation function, i.e. PBKDF2-HMAC-SHA512 with 2048 rounds and "mnemonic" as salt prefix.
+// Both mnemonic and passphrase are NFKD-normalized before generating the seed.
+type BIP39PBKDF2 struct {}
+
+//
+// Constants
+//
+const (
+	// Number of PBKDF2 rounds between two progress reports
+	seedProgressRounds = 2048
+)
+
+//
+// Exported functions
+//
+
+// Derive the seed from the specified mnemonic and passphrase as specified by BIP-0039.
+func (kdf BIP39PBKDF2) DeriveSeed(mnemonic string, passphrase string) ([]byte, error) {
+	return pbkdf2Seed(mnemonic, passphrase, seedSaltMod, seedPbkdf2Round), nil
+}
+
+// Get the salt used by BIP-0039 for generating the seed with the specified passphrase, i.e. NFKD("mnemonic" + passphrase) as bytes.
+// It can be used for debugging seed mismatches with other implementations, by comparing the exact salt bytes fed to PBKDF2.
+func SeedSalt(passphrase string) []byte {
+	return []byte(normalizeNfkd(seedSaltMod + passphrase))
+}
+
+// Get if the specified passphrases are equal once NFKD-normalized, i.e. if they generate the same seed.
+// It can be used for a passphrase confirmation field, since visually identical passphrases can be differently composed.
+// The comparison is constant-time with respect to the passphrases content.
+func PassphrasesEqual(a string, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(normalizeNfkd(a)), []byte(normalizeNfkd(b))) == 1
+}
+
+// Generate the seed from a mnemonic using the specified passphrase and key derivation function, which shall not be nil.
+// The mnemonic is validated before generating the seed, an error is returned if it's not valid.
+// WARNING: any key derivation function different from BIP39PBKDF2 generates a seed that is NOT BIP-0039 compatible.
+func (mnemonic *Mnemonic) GenerateSeedKDF(passphrase string, kdf SeedKDF) ([]byte, error) {
+	// Validate mnemonic
+	err := mnemonic.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get words joined by single separators, so that the seed doesn't depend on whitespaces
+	return kdf.DeriveSeed(strings.Join(mnemonic.WordSlice(), wordsSeparator), passphrase)
+}
+
+// Generate the seed from a mnemonic using the specified passphrase as bytes, like GenerateSeed.
+// It can be used when the passphrase is kept in a buffer that shall be wiped, since it's never converted to an (immutable) string.
+// The passphrase is NFKD-normalized like GenerateSeed, the internal copies of it are wiped before returning.
+func (mnemonic *Mnemonic) GenerateSeedBytes(passphrase []byte) ([]byte, error) {
+	// Validate mnemonic
+	err := mnemonic.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get words joined by single separators, so that the seed doesn't depend on whitespaces
+	words := normalizeNfkd(strings.Join(mnemonic.WordSlice(), wordsSeparator))
+	// Get salt by appending the normalized passphrase to the prefix
+	salt := norm.NFKD.Append([]byte(seedSaltMod), passphrase...)
+	defer Wipe(salt)
+	// Generate seed
+	return pbkdf2.Key([]byte(words), salt, seedPbkdf2Round, seedPbkdf2KeyLen, sha512.New), nil
+}
+
+// Generate the seed from a mnemonic using the specified passphrase as bytes like GenerateSeedBytes, then wipe the passphrase.
+// It can be used together with a passphrase read into a buffer, so that the passphrase doesn't linger in memory.
+// The passphrase is wiped also if an error is returned, so the caller shall not use the slice afterwards.
+func (mnemonic *Mnemonic) GenerateSeedAndWipePassphrase(passphrase []byte) ([]byte, error) {
+	defer Wipe(passphrase)
+	return mnemonic.GenerateSeedBytes(passphrase)
+}
+
+// Generate the seed from a mnemonic using the specified passphrase and number of PBKDF2 rounds, reporting the progress.
+// The progress function (if not nil) is called every 2048 rounds and at the end with the done and total rounds,
+// so it's called only once for the BIP-0039 rounds (i.e. 2048). It's called on the current goroutine, so it shall be fast.
+// WARNING: BIP-0039 specifies 2048 rounds, any other value generates a seed that is NOT BIP-0039 compatible.
+// Error is returned if the rounds are not positive or the mnemonic is not valid.
+func (mnemonic *Mnemonic) GenerateSeedProgress(passphrase string, rounds int, progress func(done, total int)) ([]byte, error) {
+	// Validate rounds
+	if rounds <= 0 {
+		return nil, fmt.Errorf("rounds %d: %w", rounds, ErrSeedRounds)
+	}
+	// Validate mnemonic
+	err := mnemonic.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get words joined by single separators, so that the seed doesn't depend on whitespaces
+	words := normalizeNfkd(strings.Join(mnemonic.WordSlice(), wordsSeparator))
+	salt := SeedSalt(passphrase)
+	defer Wipe(salt)
+	return pbkdf2Sha512Progress([]byte(words), salt, rounds, progress), nil
+}
+
+//
+// Not-exported functions
+//
+
+// Generate the seed from the specified mnemonic and passphrase using PBKDF2-HMAC-SHA512 with the specified salt prefix and rounds.
+// Both mnemonic and passphrase are NFKD-normalized before generating the seed.
+func pbkdf2Seed(mnemonic string, passphrase string, saltPrefix string, rounds int) []byte {
+	salt := normalizeNfkd(saltPrefix + passphrase)
+	return pbkdf2.Key([]byte(normalizeNfkd(mnemonic)), []byte(salt), rounds, seedPbkdf2KeyLen, sha512.New)
+}
+
+// Compute PBKDF2-HMAC-SHA512 of the specified password and salt with the specified rounds, reporting the progress.
+// Only the first block is computed, since the seed length is equal to the SHA-512 length.
+func pbkdf2Sha512Progress(password []byte, salt []byte, rounds int, progress func(done, total int)) []byte {
+	prf := hmac.New(sha512.New, password)
+
+	// First round: U1 = PRF(password, salt || INT(1))
+	prf.Write(salt)
+	prf.Write([]byte { 0, 0, 0, 1 })
+	u := prf.Sum(nil)
+	defer Wipe(u)
+	key := make([]byte, len(u))
+	copy(key, u)
+
+	// Next rounds: Uj = PRF(password, Uj-1), key = U1 ^ ... ^ Uj
+	for i := 2; i <= rounds; i++ {
+		prf.Reset()
+		prf.Write(u)
+		u = prf.Sum(u[:0])
+		for j := range key {
+			key[j] ^= u[j]
+		}
+
+		if progress != nil && i % seedProgressRounds == 0 && i != rounds {
+			progress(i, rounds)
+		}
+	}
+	if progress != nil {
+		progress(rounds, rounds)
+	}
+
+	return key
+}
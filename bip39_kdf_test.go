@@ -0,0 +1,318 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+//
+// Types
+//
+
+// Key derivation function for testing, recording its inputs
+type testSeedKDF struct {
+	Mnemonic   string
+	Passphrase string
+	Err        error
+}
+
+// Structure for passphrases comparison tests
+type testVectPassphrasesEntry struct {
+	PassphraseA string
+	PassphraseB string
+	Equal       bool
+}
+
+//
+// Variables
+//
+
+// Error returned by the test key derivation function
+var errTestSeedKDF = errors.New("Test KDF error")
+
+// Tests for passphrases comparison
+var testVectPassphrases = []testVectPassphrasesEntry {
+	testVectPassphrasesEntry { "", "", true },
+	testVectPassphrasesEntry { testPassphrase, testPassphrase, true },
+	testVectPassphrasesEntry { "caf\u00e9", "cafe\u0301", true },
+	testVectPassphrasesEntry { "\u212b", "A\u030a", true },
+	testVectPassphrasesEntry { "\uff21", "A", true },
+	testVectPassphrasesEntry { testPassphrase, "trezor", false },
+	testVectPassphrasesEntry { "caf\u00e9", "cafe", false },
+	testVectPassphrasesEntry { "pass phrase", "pass phrase ", false },
+	testVectPassphrasesEntry { "", " ", false },
+}
+
+//
+// Functions
+//
+
+// Test default key derivation function
+func TestSeedKDFDefault(t *testing.T) {
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+
+		seed, err := mnemonic.GenerateSeedKDF(testPassphrase, BIP39PBKDF2 {})
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed generation with KDF returned error: %s", currTest.Mnemonic, err.Error())
+		} else if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Mnemonic '%s' seed with KDF was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+		}
+
+		seed, err = BIP39PBKDF2 {}.DeriveSeed(currTest.Mnemonic, testPassphrase)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed derivation returned error: %s", currTest.Mnemonic, err.Error())
+		} else if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Mnemonic '%s' seed derivation was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+		}
+	}
+}
+
+// Test custom key derivation function
+func TestSeedKDFCustom(t *testing.T) {
+	// Words shall be passed joined by single spaces
+	kdf := &testSeedKDF {}
+	seed, err := MnemonicFromStringRaw(" legal winner thank year  wave sausage worth useful legal winner thank yellow\n").GenerateSeedKDF(testPassphrase, kdf)
+	if err != nil {
+		t.Errorf("Seed generation with custom KDF returned error: %s", err.Error())
+	} else if string(seed) != kdf.Mnemonic + kdf.Passphrase {
+		t.Errorf("Seed generation with custom KDF was incorrect: got: %s", seed)
+	}
+	if kdf.Mnemonic != "legal winner thank year wave sausage worth useful legal winner thank yellow" || kdf.Passphrase != testPassphrase {
+		t.Errorf("Custom KDF inputs were incorrect: got: '%s', '%s'", kdf.Mnemonic, kdf.Passphrase)
+	}
+
+	// KDF error shall be returned
+	kdf = &testSeedKDF { Err: errTestSeedKDF }
+	if _, err = MnemonicFromString(testVect[0].Mnemonic).GenerateSeedKDF(testPassphrase, kdf); !errors.Is(err, errTestSeedKDF) {
+		t.Errorf("Seed generation with failing KDF returned wrong error (%v)", err)
+	}
+
+	// Invalid mnemonic shall not reach the KDF
+	for _, currTest := range testVectMnemonicInvalid {
+		kdf = &testSeedKDF {}
+		if _, err = MnemonicFromString(currTest.Mnemonic).GenerateSeedKDF(testPassphrase, kdf); !errors.Is(err, currTest.Err) || kdf.Mnemonic != "" {
+			t.Errorf("Seed generation with KDF from invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
+
+// Test seed generation with passphrase as bytes
+func TestGenerateSeedBytes(t *testing.T) {
+	for _, currTest := range testVect {
+		seed, err := MnemonicFromString(currTest.Mnemonic).GenerateSeedBytes([]byte(testPassphrase))
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed generation with passphrase bytes returned error: %s", currTest.Mnemonic, err.Error())
+		} else if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Mnemonic '%s' seed with passphrase bytes was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+		}
+	}
+
+	// Seeds shall be the same of the string version, also for passphrases to be normalized
+	mnemonic := MnemonicFromString(testVect[0].Mnemonic)
+	for _, passphrase := range []string { "", "my_passphrase", "pässphräse", "\u212b" } {
+		expSeed, _ := mnemonic.GenerateSeed(passphrase)
+		passphraseBytes := []byte(passphrase)
+		seed, err := mnemonic.GenerateSeedBytes(passphraseBytes)
+		if err != nil {
+			t.Errorf("Seed generation with passphrase bytes %q returned error: %s", passphrase, err.Error())
+		} else if !bytes.Equal(seed, expSeed) {
+			t.Errorf("Seed with passphrase bytes %q was incorrect: expected %x, got: %x", passphrase, expSeed, seed)
+		}
+		// Passphrase shall not be modified
+		if string(passphraseBytes) != passphrase {
+			t.Errorf("Passphrase bytes %q were modified", passphrase)
+		}
+	}
+
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if _, err := MnemonicFromString(currTest.Mnemonic).GenerateSeedBytes([]byte(testPassphrase)); !errors.Is(err, currTest.Err) {
+			t.Errorf("Seed with passphrase bytes from invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
+
+// Test seed generation wiping the passphrase
+func TestGenerateSeedAndWipePassphrase(t *testing.T) {
+	for _, currTest := range testVect {
+		passphraseBytes := []byte(testPassphrase)
+		seed, err := MnemonicFromString(currTest.Mnemonic).GenerateSeedAndWipePassphrase(passphraseBytes)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed generation wiping passphrase returned error: %s", currTest.Mnemonic, err.Error())
+		} else if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Mnemonic '%s' seed wiping passphrase was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+		}
+		// Passphrase shall be wiped
+		if !bytes.Equal(passphraseBytes, make([]byte, len(testPassphrase))) {
+			t.Errorf("Mnemonic '%s' passphrase was not wiped: %x", currTest.Mnemonic, passphraseBytes)
+		}
+	}
+
+	// Passphrase to be normalized
+	mnemonic := MnemonicFromString(testVect[0].Mnemonic)
+	expSeed, _ := mnemonic.GenerateSeed("\u212b")
+	passphraseBytes := []byte("\u212b")
+	if seed, _ := mnemonic.GenerateSeedAndWipePassphrase(passphraseBytes); !bytes.Equal(seed, expSeed) {
+		t.Errorf("Seed wiping passphrase was incorrect: expected %x, got: %x", expSeed, seed)
+	}
+	if !bytes.Equal(passphraseBytes, make([]byte, len(passphraseBytes))) {
+		t.Errorf("Passphrase was not wiped: %x", passphraseBytes)
+	}
+
+	// Invalid mnemonic, passphrase shall be wiped anyway
+	for _, currTest := range testVectMnemonicInvalid {
+		passphraseBytes := []byte(testPassphrase)
+		if _, err := MnemonicFromString(currTest.Mnemonic).GenerateSeedAndWipePassphrase(passphraseBytes); !errors.Is(err, currTest.Err) {
+			t.Errorf("Seed wiping passphrase from invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+		if !bytes.Equal(passphraseBytes, make([]byte, len(testPassphrase))) {
+			t.Errorf("Passphrase of invalid mnemonic (%s) was not wiped: %x", currTest.Mnemonic, passphraseBytes)
+		}
+	}
+}
+
+// Derive seed by simply concatenating mnemonic and passphrase
+func (kdf *testSeedKDF) DeriveSeed(mnemonic string, passphrase string) ([]byte, error) {
+	if kdf.Err != nil {
+		return nil, kdf.Err
+	}
+
+	kdf.Mnemonic = mnemonic
+	kdf.Passphrase = passphrase
+	return []byte(mnemonic + passphrase), nil
+}
+
+// Test seed salt
+func TestSeedSalt(t *testing.T) {
+	testSalts := map[string]string {
+		"":             "mnemonic",
+		testPassphrase: "mnemonicTREZOR",
+		"caf\u00e9":    "mnemoniccafe\u0301",
+		"cafe\u0301":   "mnemoniccafe\u0301",
+		"\u212b":       "mnemonicA\u030a",
+		"pass phrase ": "mnemonicpass phrase ",
+	}
+	for passphrase, expSalt := range testSalts {
+		if salt := SeedSalt(passphrase); string(salt) != expSalt {
+			t.Errorf("Seed salt for passphrase %q was incorrect: expected %q, got: %q", passphrase, expSalt, salt)
+		}
+	}
+
+	// Salt shall be the one used for generating the seed
+	for _, currTest := range testVect {
+		words := []byte(strings.Join(strings.Fields(currTest.Mnemonic), " "))
+		seed := pbkdf2.Key(words, SeedSalt(testPassphrase), seedPbkdf2Round, seedPbkdf2KeyLen, sha512.New)
+		if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Mnemonic '%s' seed with salt was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+		}
+	}
+}
+
+// Test passphrases comparison
+func TestPassphrasesEqual(t *testing.T) {
+	for _, currTest := range testVectPassphrases {
+		if equal := PassphrasesEqual(currTest.PassphraseA, currTest.PassphraseB); equal != currTest.Equal {
+			t.Errorf("Passphrases %q and %q equality was incorrect: expected %t, got: %t", currTest.PassphraseA, currTest.PassphraseB, currTest.Equal, equal)
+		}
+	}
+
+	// Equal passphrases shall generate the same seed
+	mnemonic := MnemonicFromStringRaw(testVect[0].Mnemonic)
+	seedsDiffer, err := mnemonic.SeedsDiffer("caf\u00e9", "cafe\u0301")
+	if err != nil {
+		t.Errorf("Seeds comparison returned error: %s", err.Error())
+	} else if seedsDiffer {
+		t.Errorf("Seeds of equal passphrases were different")
+	}
+}
+
+// Test seed generation with progress
+func TestGenerateSeedProgress(t *testing.T) {
+	for _, currTest := range testVect {
+		// Default rounds shall report the progress once
+		var progressDone [][2]int
+		seed, err := MnemonicFromString(currTest.Mnemonic).GenerateSeedProgress(testPassphrase, seedPbkdf2Round, func(done, total int) {
+			progressDone = append(progressDone, [2]int { done, total })
+		})
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed generation with progress returned error: %s", currTest.Mnemonic, err.Error())
+		} else if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Mnemonic '%s' seed with progress was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+		}
+		if !reflect.DeepEqual(progressDone, [][2]int { { seedPbkdf2Round, seedPbkdf2Round } }) {
+			t.Errorf("Mnemonic '%s' seed generation progress was incorrect: got: %v", currTest.Mnemonic, progressDone)
+		}
+	}
+
+	// Seeds shall be the same of the ones with custom rounds, also without progress function
+	mnemonic := MnemonicFromString(testVect[0].Mnemonic)
+	testProgress := map[int][][2]int {
+		1:    [][2]int { { 1, 1 } },
+		2047: [][2]int { { 2047, 2047 } },
+		4096: [][2]int { { 2048, 4096 }, { 4096, 4096 } },
+		5000: [][2]int { { 2048, 5000 }, { 4096, 5000 }, { 5000, 5000 } },
+	}
+	for testRounds, expProgress := range testProgress {
+		expSeed, _ := mnemonic.GenerateSeedWithSalt(testPassphrase, seedSaltMod, testRounds)
+
+		var progressDone [][2]int
+		seed, err := mnemonic.GenerateSeedProgress(testPassphrase, testRounds, func(done, total int) {
+			progressDone = append(progressDone, [2]int { done, total })
+		})
+		if err != nil {
+			t.Errorf("Seed generation with progress and %d rounds returned error: %s", testRounds, err.Error())
+		} else if !bytes.Equal(seed, expSeed) {
+			t.Errorf("Seed with progress and %d rounds was incorrect: expected %x, got: %x", testRounds, expSeed, seed)
+		}
+		if !reflect.DeepEqual(progressDone, expProgress) {
+			t.Errorf("Seed generation progress with %d rounds was incorrect: expected %v, got: %v", testRounds, expProgress, progressDone)
+		}
+
+		if seed, _ = mnemonic.GenerateSeedProgress(testPassphrase, testRounds, nil); !bytes.Equal(seed, expSeed) {
+			t.Errorf("Seed without progress and %d rounds was incorrect: expected %x, got: %x", testRounds, expSeed, seed)
+		}
+	}
+
+	// Invalid rounds
+	for _, testRounds := range []int { 0, -1 } {
+		if _, err := mnemonic.GenerateSeedProgress(testPassphrase, testRounds, nil); !errors.Is(err, ErrSeedRounds) {
+			t.Errorf("Seed generation with progress and invalid rounds (%d) returned wrong error (%v)", testRounds, err)
+		}
+	}
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if _, err := MnemonicFromString(currTest.Mnemonic).GenerateSeedProgress(testPassphrase, seedPbkdf2Round, nil); !errors.Is(err, currTest.Err) {
+			t.Errorf("Seed generation with progress from invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
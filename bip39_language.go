@@ -0,0 +1,284 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains the supported languages for bip39 package.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+//
+// Types
+//
+
+// Language type
+type Language int
+
+// Structure for words list
+// The sorted words and the index map are built once, the first time they are needed,
+// so that only the languages actually used are initialized.
+// The initialization is guarded by a sync.Once and the built data is never modified afterwards,
+// so a words list can be used concurrently by many goroutines.
+type wordsList struct {
+	// Language name
+	name string
+	// Words, in the BIP-0039 order
+	words []string
+	// Words sorted lexicographically, for searching by prefix
+	sortedWords []string
+	// Map from word to its index, so that the lookup doesn't depend on the words order
+	wordsIdxMap map[string]int
+	// Guard for building sorted words and index map
+	initOnce sync.Once
+}
+
+//
+// Constants
+//
+const (
+	// Supported languages
+	// The values are the language codes defined by BIP-0085
+	LangEnglish            Language = 0
+	LangKorean             Language = 2
+	LangSpanish            Language = 3
+	LangChineseSimplified  Language = 4
+	LangChineseTraditional Language = 5
+	LangFrench             Language = 6
+	LangCzech              Language = 8
+	LangPortuguese         Language = 9
+
+	// Language of words not belonging to any supported language (e.g. in LanguageBreakdown), it's not a supported language
+	LangUnknown Language = -1
+)
+
+//
+// Variables
+//
+var (
+	// ErrUnsupportedLanguage is returned when trying to use a language that is not supported
+	ErrUnsupportedLanguage = errors.New("The specified language is not supported")
+	// ErrLanguageNotDetected is returned when the language of a mnemonic cannot be detected
+	ErrLanguageNotDetected = errors.New("The language of the mnemonic cannot be detected")
+
+	// Words list for each supported language
+	languageWordsListMap = map[Language]*wordsList {
+		LangEnglish            : newWordsList("english", wordsListEn),
+		LangSpanish            : newWordsList("spanish", wordsListSp),
+		LangFrench             : newWordsList("french", wordsListFr),
+		LangKorean             : newWordsList("korean", wordsListKo),
+		LangCzech              : newWordsList("czech", wordsListCs),
+		LangPortuguese         : newWordsList("portuguese", wordsListPt),
+		LangChineseSimplified  : newWordsList("chinese_simplified", wordsListZhCn),
+		LangChineseTraditional : newWordsList("chinese_traditional", wordsListZhTw),
+	}
+	// Language codes (ISO 639-1, with region for Chinese) accepted by ParseLanguage in addition to the names
+	languageCodesMap = map[string]Language {
+		"en"    : LangEnglish,
+		"es"    : LangSpanish,
+		"fr"    : LangFrench,
+		"ko"    : LangKorean,
+		"cs"    : LangCzech,
+		"pt"    : LangPortuguese,
+		"zh_cn" : LangChineseSimplified,
+		"zh_tw" : LangChineseTraditional,
+	}
+)
+
+//
+// Exported functions
+//
+
+// Detect the language of the specified mnemonic string, i.e. the language whose words list contains all the mnemonic words.
+// If the words belong to more than one language, the language for which the mnemonic is valid (i.e. valid checksum) is returned.
+// If the language cannot be uniquely determined, ErrLanguageNotDetected is returned.
+func DetectLanguage(mnemonic string) (Language, error) {
+	words := strings.Fields(NormalizeMnemonic(mnemonic))
+	if len(words) == 0 {
+		return 0, ErrLanguageNotDetected
+	}
+
+	// Get the languages containing all the words
+	var candidates []Language
+	for lang, wordsList := range languageWordsListMap {
+		if wordsList.containsAll(words) {
+			candidates = append(candidates, lang)
+		}
+	}
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+
+	// If more than one, keep only the languages for which the mnemonic is valid
+	var validCandidates []Language
+	for _, lang := range candidates {
+		_, err := MnemonicFromStringRaw(strings.Join(words, wordsSeparator)).toEntropy(languageWordsListMap[lang].wordIndex)
+		if err == nil {
+			validCandidates = append(validCandidates, lang)
+		}
+	}
+	if len(validCandidates) == 1 {
+		return validCandidates[0], nil
+	}
+
+	return 0, ErrLanguageNotDetected
+}
+
+// Get the list of supported languages, sorted by value.
+func SupportedLanguages() []Language {
+	langs := make([]Language, 0, len(languageWordsListMap))
+	for lang := range languageWordsListMap {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		return langs[i] < langs[j]
+	})
+	return langs
+}
+
+// Iterate the words list of the specified language in the BIP-0039 order, calling the specified function with the index and the word.
+// The iteration stops early if the function returns false. The words list is not copied, so it's cheap also for a partial scan.
+// Error is returned if the language is not supported.
+func EachWord(lang Language, fn func(index int, word string) bool) error {
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return err
+	}
+
+	for i, word := range wordsList.words {
+		if !fn(i, word) {
+			break
+		}
+	}
+	return nil
+}
+
+// Get the language name (e.g. "english"), or "unknown" if the language is not supported.
+func (lang Language) String() string {
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return "unknown"
+	}
+	return wordsList.name
+}
+
+// Parse a language from its name (i.e. the one returned by Language.String, e.g. "english") or its code (e.g. "en", "zh-cn").
+// The string is case-insensitive and leading and trailing whitespaces are ignored, "-" and "_" are equivalent (e.g. "Chinese-Simplified").
+// ErrUnsupportedLanguage is returned if the language is unknown or not supported.
+func ParseLanguage(str string) (Language, error) {
+	str = strings.Replace(strings.ToLower(strings.TrimSpace(str)), "-", "_", -1)
+
+	// Name
+	for lang, wordsList := range languageWordsListMap {
+		if wordsList.name == str {
+			return lang, nil
+		}
+	}
+	// Code
+	if lang, ok := languageCodesMap[str]; ok {
+		return lang, nil
+	}
+
+	return 0, fmt.Errorf("language %q: %w", str, ErrUnsupportedLanguage)
+}
+
+//
+// Not-exported functions
+//
+
+// Create a words list with the specified language name from the specified words, which shall be NFKD-normalized.
+func newWordsList(name string, words []string) *wordsList {
+	return &wordsList {
+		name:  name,
+		words: words,
+	}
+}
+
+// Build the sorted words and the index map, if not already built.
+// It's safe for concurrent use.
+func (wordsList *wordsList) init() {
+	wordsList.initOnce.Do(func() {
+		// Sort words
+		wordsList.sortedWords = make([]string, len(wordsList.words))
+		copy(wordsList.sortedWords, wordsList.words)
+		sort.Strings(wordsList.sortedWords)
+
+		// Build map from word to index
+		wordsList.wordsIdxMap = make(map[string]int, len(wordsList.words))
+		for i, word := range wordsList.words {
+			wordsList.wordsIdxMap[word] = i
+		}
+	})
+}
+
+// Get the words sorted lexicographically.
+func (wordsList *wordsList) sorted() []string {
+	wordsList.init()
+	return wordsList.sortedWords
+}
+
+// Get the index of the specified word, -1 if not found.
+// The word is NFKD-normalized before searching it, so both composed and decomposed forms are accepted.
+func (wordsList *wordsList) wordIndex(word string) int {
+	wordsList.init()
+	if wordIdx, ok := wordsList.wordsIdxMap[normalizeNfkd(word)]; ok {
+		return wordIdx
+	}
+	return -1
+}
+
+// Get the index of the specified word, -1 if not found.
+// Differently from wordIndex, the word shall be exactly as in the words list (i.e. NFKD-normalized).
+func (wordsList *wordsList) exactWordIndex(word string) int {
+	wordsList.init()
+	if wordIdx, ok := wordsList.wordsIdxMap[word]; ok {
+		return wordIdx
+	}
+	return -1
+}
+
+// Get if all the specified words are contained in the words list.
+func (wordsList *wordsList) containsAll(words []string) bool {
+	for _, word := range words {
+		if wordsList.wordIndex(word) == -1 {
+			return false
+		}
+	}
+	return true
+}
+
+// Get the words list of the specified language.
+func getWordsList(lang Language) (*wordsList, error) {
+	wordsList, ok := languageWordsListMap[lang]
+	if !ok {
+		return nil, fmt.Errorf("language %d: %w", lang, ErrUnsupportedLanguage)
+	}
+	return wordsList, nil
+}
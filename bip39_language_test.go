@@ -0,0 +1,768 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"golang.org/x/text/unicode/norm"
+)
+
+//
+// Types
+//
+
+// Language test vector entry structure
+type testVectLangEntry struct {
+	Lang     Language
+	Entropy  string
+	Mnemonic string
+	Seed     string
+}
+
+//
+// Variables
+//
+
+// Tests for languages other than English (seeds generated with testPassphrase)
+var testVectLang = []testVectLangEntry {
+	testVectLangEntry {
+		Lang:     LangSpanish,
+		Entropy:  "00000000000000000000000000000000",
+		Mnemonic: "ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco ábaco abierto",
+		Seed:     "29a2ee16de47d07025de37e7d9c596869439f9bcd26a702d2bae64db2bf0f68383841c5444b5b3bd39dd720d2ebe59969e110e5955c8e6d32c6c3294fd87439b",
+	},
+	testVectLangEntry {
+		Lang:     LangSpanish,
+		Entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		Mnemonic: "ligero vista talar yogur venta queso yacer trozo ligero vista talar zafiro",
+		Seed:     "1580aa5d5d67057b3a0a12253c283b93921851555529d0bbe9634349d641029216f791ddce3527819d44d833a0df3500b15fd8ba4cae7ca24e1464b9167de633",
+	},
+	testVectLangEntry {
+		Lang:     LangSpanish,
+		Entropy:  "8080808080808080808080808080808080808080808080808080808080808080",
+		Mnemonic: "lino admitir bolero abrir álbum dejar acelga aprender lino admitir bolero abrir álbum dejar acelga aprender lino admitir bolero abrir álbum dejar acelga aumento",
+		Seed:     "dd095dddb50de059f5cb6932d529ad37dd32d40f72da3d0c7671ffc6bd967b4392fe233e5e9a4d9e5e60413160ae215e34375db85e95ccbab4fd4712f32216ab",
+	},
+	testVectLangEntry {
+		Lang:     LangSpanish,
+		Entropy:  "9e885d952ad362caeb4efe34a8e91bd2",
+		Mnemonic: "obra diadema gorila farmacia colgar gorra pausa talar cocina duda dragón optar",
+		Seed:     "fcf6ebfc7d9eebab56ca868cbd2d5d05a6f2142ba903c52855dad4ab8c0c2cf6b4e047a2dd97cf382ae717dc18d155a45fc798e6f0a0b89971a4224e2a285701",
+	},
+	testVectLangEntry {
+		Lang:     LangFrench,
+		Entropy:  "00000000000000000000000000000000",
+		Mnemonic: "abaisser abaisser abaisser abaisser abaisser abaisser abaisser abaisser abaisser abaisser abaisser abeille",
+		Seed:     "3bf3366c40256d7e2fca716fddf8673425c7c7e444af290ee1edf1bbf095e6e78a7190253f3e46f1e2069345d4b05ac17b242faa225c0a3e4d268976744e0698",
+	},
+	testVectLangEntry {
+		Lang:     LangFrench,
+		Entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		Mnemonic: "implorer visage sonnette voyage véloce pourpre volaille tribunal implorer visage sonnette voyage véloce pourpre volaille tribunal implorer vinaigre",
+		Seed:     "dcf42783150cdb92672c9ea7d13f145401661f10b89bfb012a803ca7713e97181ee28ac327a982060a7f8aaa6e8c649ca2c5b83c24458393fe41739ced31d987",
+	},
+	testVectLangEntry {
+		Lang:     LangFrench,
+		Entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		Mnemonic: "zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie zoologie valable",
+		Seed:     "b5e96f552ba44ec827c1bc5ef362e8cea68dd6f36f2c8640aeb171cf9b66198fbdf155fdbcf7dc505431068f972a92442f33cda0065afc1e9a7f5f7097ea6c6a",
+	},
+	testVectLangEntry {
+		Lang:     LangFrench,
+		Entropy:  "9e885d952ad362caeb4efe34a8e91bd2",
+		Mnemonic: "monument dépenser féroce entasser comédie ferveur optique sonnette codifier discuter dioxyde nerveux",
+		Seed:     "d322acd69a849cce8719674eeb7cd76520de01ea35210012a44a5dcc19faf285202c3fb3c749a46d338ad54ddd398029ee308ee352a89f65180dbd3ff750dd50",
+	},
+	testVectLangEntry {
+		Lang:     LangKorean,
+		Entropy:  "00000000000000000000000000000000",
+		Mnemonic: "가격 가격 가격 가격 가격 가격 가격 가격 가격 가격 가격 가능",
+		Seed:     "a253d07f616223e337b6fa257632a2cc37e1ba36ff0bc7cf5a943366fa1b9ef02d6aa0333da51c17902951634b8aa81b6692a194b07f4f8c542335d73c96aad3",
+	},
+	testVectLangEntry {
+		Lang:     LangKorean,
+		Entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		Mnemonic: "실장 활동 큰절 흔적 형제 제대로 훈련 한글 실장 활동 큰절 흔적 형제 제대로 훈련 한글 실장 환갑",
+		Seed:     "3162bc17e0f2f01ee571022444d2c5fbddf6a68dedfe734c319fb574592e9c0328f6526116b3b0b025b23391781d0bef8f43bc8ddc2b054b9f52e1fd6a88e3d2",
+	},
+	testVectLangEntry {
+		Lang:     LangKorean,
+		Entropy:  "8080808080808080808080808080808080808080808080808080808080808080",
+		Mnemonic: "실현 감소 기법 가상 걱정 무슨 가족 공간 실현 감소 기법 가상 걱정 무슨 가족 공간 실현 감소 기법 가상 걱정 무슨 가족 구속",
+		Seed:     "9a0ec04a48287ae628d61428f921de5f40fc1035f21883798e05c36f9705b2525a00ebd6bb89fcae9b8af8e9861d0083de331199d6b85b24cff598609a49b305",
+	},
+	testVectLangEntry {
+		Lang:     LangKorean,
+		Entropy:  "9e885d952ad362caeb4efe34a8e91bd2",
+		Mnemonic: "원고 물질 생일 부산 마요네즈 생활 일찍 큰절 동화책 반성 반드시 의식",
+		Seed:     "8d148c7f8ed529d7a88fe2bc8bff574b56406f9928ab5426df793f4d3a5121c7c6974c856ad20f66ecf04fbecd3bc025912b3e41d500f1e5be896505e01d08d6",
+	},
+	testVectLangEntry {
+		Lang:     LangCzech,
+		Entropy:  "00000000000000000000000000000000",
+		Mnemonic: "abdikace abdikace abdikace abdikace abdikace abdikace abdikace abdikace abdikace abdikace abdikace agrese",
+		Seed:     "872501bed75c98fbf943a67907bf394995f337e9adfa23687282d1135c262421715a0bcccfe2d3f5f8b72c8e2fa12a7a7267f8047b744557f4a9d49d11ccc75f",
+	},
+	testVectLangEntry {
+		Lang:     LangCzech,
+		Entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		Mnemonic: "obrazec znak uznat zubovina zeman skupina zrcadlo vzchopit obrazec znak uznat zubovina zeman skupina zrcadlo vzchopit obrazec zmije",
+		Seed:     "93ff13dee31715a6568609df3f7ea295d58728a65611ea03620d2105a0efbbaa39d8b6541b3b5a57a25dbdfd5006f0c58779a7ed196e25a1a97d1442e3f080fa",
+	},
+	testVectLangEntry {
+		Lang:     LangCzech,
+		Entropy:  "8080808080808080808080808080808080808080808080808080808080808080",
+		Mnemonic: "obvinit bageta doma amputace bidlo jedle arogance butik obvinit bageta doma amputace bidlo jedle arogance butik obvinit bageta doma amputace bidlo jedle arogance cihla",
+		Seed:     "bb8b82baced0db7764e102d1d1f68035269e84ec6c1ed0e09b2a31094330967aff9e1a490a407fef736fb8719c60bfb8cb0be9b27fce97c3b619409c195e2f1d",
+	},
+	testVectLangEntry {
+		Lang:     LangCzech,
+		Entropy:  "9e885d952ad362caeb4efe34a8e91bd2",
+		Mnemonic: "pokoj jogurt malovat kroupa holub malvice rachot uznat hnout kasa karamel potupa",
+		Seed:     "f3922b8086d559436ba2d04bc2aae4174e6504d7d4d451f7282d0b41a1b8cc958b45a896985e0b9316ad09c62f7d62dac85bc3d3e2e2423bcad3336412fd33f8",
+	},
+	testVectLangEntry {
+		Lang:     LangPortuguese,
+		Entropy:  "00000000000000000000000000000000",
+		Mnemonic: "abacate abacate abacate abacate abacate abacate abacate abacate abacate abacate abacate abater",
+		Seed:     "ab9742b024a1e8bd241b76f8b3a157e9d442da60277bc8f36b8b23afe163de79414fb49fd1a8dd26f4ea7f0dc965c760b3b80727557bdca61e1f0b0f069952f2",
+	},
+	testVectLangEntry {
+		Lang:     LangPortuguese,
+		Entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		Mnemonic: "imortal vinheta sogro xerife veleiro pomba volumoso tratador imortal vinheta sogro xerife veleiro pomba virtude",
+		Seed:     "a554976b09318e308a8c97cd1c5dab83fcd5362e05220bbdaad3e4c2420f243c844fdfa1b3f5db77d20d715032ba3a7744e3208e58ec5e9d746b4849685dc5bf",
+	},
+	testVectLangEntry {
+		Lang:     LangPortuguese,
+		Entropy:  "808080808080808080808080808080808080808080808080",
+		Mnemonic: "inapto acirrar barulho abotoar afivelar coruja abutre amostra inapto acirrar barulho abotoar afivelar coruja abutre amostra inapto afastar",
+		Seed:     "27655f35bce384537dac3fe41b108eb411c2c034953e997b0eb51ca816677fbd966051406abc6aea3d22394a5f7e0264c7ba02c4a945e95b27e17574a42ea59a",
+	},
+	testVectLangEntry {
+		Lang:     LangPortuguese,
+		Entropy:  "ffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		Mnemonic: "zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido zumbido turma",
+		Seed:     "7093f7031cff03edc3a38edb221293edaba280c7aeaf51508cfc4824edfe4cc74edaad25b734f11b74da30ea48f1b7c9f960ece6c18c2c908d3d6b845f01bfef",
+	},
+	testVectLangEntry {
+		Lang:     LangPortuguese,
+		Entropy:  "9e885d952ad362caeb4efe34a8e91bd29e885d952ad362caeb4efe34a8e91bd2",
+		Mnemonic: "micro crosta fartura emprego chatice fatia oleoso sogro centeio defesa dedal nadador teatral camuflar embutido enxerto extinto engenho rodeio virtude desfiado girafa gabarito despesa",
+		Seed:     "9a11663b37b222ed1e29f2c34bffb29d6bd74cba6111ce82ba64b975443a1a8391c417bd788fb1a7b2f5b803a6a965a51e744e09aba370033c9bbf406b45a21e",
+	},
+	testVectLangEntry {
+		Lang:     LangChineseSimplified,
+		Entropy:  "00000000000000000000000000000000",
+		Mnemonic: "的 的 的 的 的 的 的 的 的 的 的 在",
+		Seed:     "7f7c7f91ef81f0fb6a3b95b346c50e6472c1d554f8ba90637bad8afce4a4de87c322c1acafa2f6f5e9a8f9b2d2c40e9d389efdc2adbe4445c21a0939fb39e91f",
+	},
+	testVectLangEntry {
+		Lang:     LangChineseSimplified,
+		Entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		Mnemonic: "枪 疫 霉 尝 俩 闹 饿 贤 枪 疫 霉 尝 俩 闹 饿 贤 枪 殿",
+		Seed:     "74187bbdce2dba25eed3b9aebdc65dcb7c61e74c58591451d47f9c7b7b17545a527880640bfb9cab36989eba1edddf57bfce7340697926de7f0b9ec1e0345c38",
+	},
+	testVectLangEntry {
+		Lang:     LangChineseSimplified,
+		Entropy:  "8080808080808080808080808080808080808080808080808080808080808080",
+		Mnemonic: "壤 对 据 人 三 谈 我 表 壤 对 据 人 三 谈 我 表 壤 对 据 人 三 谈 我 五",
+		Seed:     "470e61f7e976fa18c7d559e842ba7f39849b2f72ef15428f4276c5160002f36416cd22c2a86bb686d69f6b91818538aa57ae1aab27b3181b92132c59be2b329b",
+	},
+	testVectLangEntry {
+		Lang:     LangChineseSimplified,
+		Entropy:  "9e885d952ad362caeb4efe34a8e91bd2",
+		Mnemonic: "蒙 台 脱 纪 构 硫 浆 霉 感 仅 鱼 汤",
+		Seed:     "decd71d2824a1bbadf8c3942f43504a648a8db5f1cac0ae1d0f787728353002a12644b1a6b725147c91682e7f33aec13493b9a779a7dd8ee15a5d10ab21d49e5",
+	},
+	testVectLangEntry {
+		Lang:     LangChineseTraditional,
+		Entropy:  "00000000000000000000000000000000",
+		Mnemonic: "的 的 的 的 的 的 的 的 的 的 的 在",
+		Seed:     "7f7c7f91ef81f0fb6a3b95b346c50e6472c1d554f8ba90637bad8afce4a4de87c322c1acafa2f6f5e9a8f9b2d2c40e9d389efdc2adbe4445c21a0939fb39e91f",
+	},
+	testVectLangEntry {
+		Lang:     LangChineseTraditional,
+		Entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		Mnemonic: "槍 疫 黴 嘗 倆 鬧 餓 賢 槍 疫 黴 嘗 倆 鬧 餓 賢 槍 殿",
+		Seed:     "2b219a8be0a8e27a6b50d0a74eb42175bd23e22cf4081518c9a74cbfe2cbace46f0adad8d390f8a2ac30feb26226db14fbc545d18ba0e56a853cbf103c92539e",
+	},
+	testVectLangEntry {
+		Lang:     LangChineseTraditional,
+		Entropy:  "8080808080808080808080808080808080808080808080808080808080808080",
+		Mnemonic: "壤 對 據 人 三 談 我 表 壤 對 據 人 三 談 我 表 壤 對 據 人 三 談 我 五",
+		Seed:     "d029fc9737b801cb4f9aadf5feed02a117b76ead7058e055cc39cb44864023eb492e6a15c68569d6a03a5b11bf15a456c64e1781a553589b47ab569801239a00",
+	},
+	testVectLangEntry {
+		Lang:     LangChineseTraditional,
+		Entropy:  "9e885d952ad362caeb4efe34a8e91bd2",
+		Mnemonic: "蒙 台 脫 紀 構 硫 漿 黴 感 僅 魚 湯",
+		Seed:     "27ca577f0318b6c6067acce7aefacd12bc9fbbc8e365fdc16bfc0ffd76379b0768dc56877f19eee4c1222dfb5a94a5516c5707e6a6ad070af9a0fe7f7799ac5e",
+	},
+}
+
+// Tests for language detection failure
+var testVectDetectLanguageInvalid = []string {
+	"",
+	"notexistent words",
+	// English and Spanish words
+	"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abierto",
+}
+
+// Mnemonics valid in more than one language (i.e. characters shared by Chinese Simplified and Traditional)
+var testVectDetectLanguageAmbiguous = []string {
+	"的 的 的 的 的 的 的 的 的 的 的 在",
+	"的 的 的 的 的 的 的 的 的 的 的 的 的 的 的 的 的 的 的 的 的 的 的 性",
+}
+
+//
+// Functions
+//
+
+// Test languages vector
+func TestLanguageVector(t *testing.T) {
+	for _, currTest := range testVectLang {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+
+		// Create mnemonic from entropy
+		mnemonic, err := MnemonicFromEntropyLang(entropy, currTest.Lang)
+		if err != nil {
+			t.Errorf("Mnemonic from entropy %s returned error: %s", currTest.Entropy, err.Error())
+			continue
+		}
+		if mnemonic.Words != currTest.Mnemonic {
+			t.Errorf("Mnemonic from entropy was incorrect: expected %s, got: %s", currTest.Mnemonic, mnemonic.Words)
+		}
+
+		// Get entropy back from mnemonic
+		gotEntropy, err := mnemonic.ToEntropy()
+		if err != nil {
+			t.Errorf("Mnemonic '%s' to entropy returned error: %s", currTest.Mnemonic, err.Error())
+		} else if !bytes.Equal(gotEntropy, entropy) {
+			t.Errorf("Mnemonic '%s' to entropy was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Entropy, gotEntropy)
+		}
+
+		// Generate seed from mnemonic
+		seed, err := mnemonic.GenerateSeed(testPassphrase)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed generation returned error: %s", currTest.Mnemonic, err.Error())
+		} else if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Mnemonic '%s' seed generation was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+		}
+
+		// The same seed shall be generated from the composed (NFC) form of the mnemonic
+		mnemonic = MnemonicFromStringLang(norm.NFC.String(currTest.Mnemonic), currTest.Lang)
+		seed, err = mnemonic.GenerateSeed(testPassphrase)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' (NFC) seed generation returned error: %s", currTest.Mnemonic, err.Error())
+		} else if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Mnemonic '%s' (NFC) seed generation was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+		}
+	}
+}
+
+// Test mnemonic from string with language
+func TestLanguageFromString(t *testing.T) {
+	for _, currTest := range testVectLang {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+
+		// Mnemonic with the specified language
+		gotEntropy, err := MnemonicFromStringLang(currTest.Mnemonic, currTest.Lang).ToEntropy()
+		if err != nil {
+			t.Errorf("Mnemonic '%s' (language %d) to entropy returned error: %s", currTest.Mnemonic, currTest.Lang, err.Error())
+		} else if !bytes.Equal(gotEntropy, entropy) {
+			t.Errorf("Mnemonic '%s' (language %d) to entropy was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Lang, currTest.Entropy, gotEntropy)
+		}
+
+		// Mnemonic with detected language (ambiguous ones fall back to English and are not valid)
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+		if isAmbiguousMnemonic(currTest.Mnemonic) {
+			if mnemonic.IsValid() {
+				t.Errorf("Ambiguous mnemonic '%s' is valid", currTest.Mnemonic)
+			}
+		} else if !mnemonic.IsValid() {
+			t.Errorf("Mnemonic '%s' with detected language is not valid", currTest.Mnemonic)
+		}
+	}
+
+	// Valid mnemonic in the wrong language
+	mnemonic := MnemonicFromStringLang(testVect[0].Mnemonic, LangSpanish)
+	if err := mnemonic.Validate(); !errors.Is(err, ErrInvalidWord) {
+		t.Errorf("Mnemonic '%s' with wrong language returned wrong error (%v)", mnemonic.Words, err)
+	}
+	// Unsupported language
+	mnemonic = MnemonicFromStringLang(testVect[0].Mnemonic, Language(-1))
+	if err := mnemonic.Validate(); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Mnemonic '%s' with unsupported language returned wrong error (%v)", mnemonic.Words, err)
+	}
+}
+
+// Test entropy round-trip for all languages and words numbers
+func TestLanguageRoundTrip(t *testing.T) {
+	for lang := range languageWordsListMap {
+		for _, testWordsNum := range testVectWordsNumValid {
+			// Create mnemonic from words number
+			mnemonic, err := MnemonicFromWordsNumLang(testWordsNum, lang)
+			if err != nil {
+				t.Errorf("Mnemonic from words number %d (language %d) returned error: %s", testWordsNum, lang, err.Error())
+				continue
+			}
+
+			// Get entropy back and create the mnemonic again
+			entropy, err := mnemonic.ToEntropy()
+			if err != nil {
+				t.Errorf("Mnemonic '%s' to entropy returned error: %s", mnemonic.Words, err.Error())
+				continue
+			}
+			gotMnemonic, _ := MnemonicFromEntropyLang(entropy, lang)
+			if gotMnemonic.Words != mnemonic.Words {
+				t.Errorf("Mnemonic round-trip was incorrect: expected %s, got: %s", mnemonic.Words, gotMnemonic.Words)
+			}
+		}
+	}
+}
+
+// Test words lists
+func TestLanguageWordsList(t *testing.T) {
+	for lang, wordsList := range languageWordsListMap {
+		// Words shall be 2048, unique and NFKD-normalized
+		wordsList.init()
+		if len(wordsList.words) != wordsListLen || len(wordsList.wordsIdxMap) != wordsListLen {
+			t.Errorf("Words list of language %d has invalid length", lang)
+		}
+		for _, word := range wordsList.words {
+			if !norm.NFKD.IsNormalString(word) {
+				t.Errorf("Word '%s' of language %d is not NFKD-normalized", word, lang)
+			}
+		}
+	}
+}
+
+// Test supported languages
+func TestSupportedLanguages(t *testing.T) {
+	langs := SupportedLanguages()
+	if len(langs) != len(languageWordsListMap) {
+		t.Errorf("Supported languages number was incorrect: expected %d, got: %d", len(languageWordsListMap), len(langs))
+	}
+
+	names := make(map[string]bool)
+	for i, lang := range langs {
+		if i > 0 && langs[i - 1] >= lang {
+			t.Errorf("Supported languages are not sorted: %v", langs)
+		}
+		// Each language shall resolve to a 2048 words list
+		wordsList, err := getWordsList(lang)
+		if err != nil {
+			t.Errorf("Supported language %d words list returned error: %s", lang, err.Error())
+		} else if len(wordsList.words) != wordsListLen {
+			t.Errorf("Supported language %d words list length was incorrect: expected %d, got: %d", lang, wordsListLen, len(wordsList.words))
+		}
+		// Names shall be unique
+		if lang.String() == "" || lang.String() == "unknown" || names[lang.String()] {
+			t.Errorf("Supported language %d name '%s' is not valid", lang, lang.String())
+		}
+		names[lang.String()] = true
+	}
+
+	if LangEnglish.String() != "english" {
+		t.Errorf("Language name was incorrect: expected english, got: %s", LangEnglish.String())
+	}
+	if Language(-1).String() != "unknown" {
+		t.Errorf("Unsupported language name was incorrect: expected unknown, got: %s", Language(-1).String())
+	}
+}
+
+// Test words list lookup with unsorted words
+func TestWordsListUnsorted(t *testing.T) {
+	// Reverse English words list
+	words := make([]string, len(wordsListEn))
+	for i, word := range wordsListEn {
+		words[len(wordsListEn) - 1 - i] = word
+	}
+	wordsList := newWordsList("reversed", words)
+
+	for i, word := range words {
+		if wordIdx := wordsList.wordIndex(word); wordIdx != i {
+			t.Errorf("Word '%s' index in unsorted words list was incorrect: expected %d, got: %d", word, i, wordIdx)
+		}
+	}
+	if wordIdx := wordsList.wordIndex("notexistent"); wordIdx != -1 {
+		t.Errorf("Not existent word index was incorrect: expected -1, got: %d", wordIdx)
+	}
+}
+
+// Test concurrent use of words lists, which are lazily initialized
+// It's meaningful when run with the race detector (i.e. go test -race)
+func TestWordsListConcurrent(t *testing.T) {
+	const goroutinesNum = 32
+
+	// Use a new words list, so that it's initialized concurrently
+	wordsList := newWordsList("concurrent", wordsListEn)
+
+	var wg sync.WaitGroup
+	for i := 0; i < goroutinesNum; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			word := wordsListEn[i]
+			if wordIdx := wordsList.wordIndex(word); wordIdx != i {
+				t.Errorf("Word '%s' index was incorrect: expected %d, got: %d", word, i, wordIdx)
+			}
+			if sortedWords := wordsList.sorted(); len(sortedWords) != len(wordsListEn) {
+				t.Errorf("Sorted words list length was incorrect: expected %d, got: %d", len(wordsListEn), len(sortedWords))
+			}
+
+			// Exported functions using the global words lists
+			for _, currTest := range testVectLang {
+				if err := MnemonicFromStringLang(currTest.Mnemonic, currTest.Lang).Validate(); err != nil {
+					t.Errorf("Mnemonic '%s' concurrent validation returned error: %s", currTest.Mnemonic, err.Error())
+				}
+			}
+			if wordsIdx, err := MnemonicFromString(testVect[0].Mnemonic).WordIndices(LangEnglish); err != nil || len(wordsIdx) != WordsNum12 {
+				t.Errorf("Concurrent word indexes were incorrect (%v)", err)
+			}
+			if words := CompletePrefix("aban", LangEnglish); len(words) != 1 {
+				t.Errorf("Concurrent prefix completion was incorrect: got: %v", words)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+// Test language detection
+func TestDetectLanguage(t *testing.T) {
+	// Test vectors of all languages
+	for _, currTest := range testVectLang {
+		lang, err := DetectLanguage(currTest.Mnemonic)
+		// Ambiguous mnemonics shall not be detected
+		if isAmbiguousMnemonic(currTest.Mnemonic) {
+			if !errors.Is(err, ErrLanguageNotDetected) {
+				t.Errorf("Ambiguous mnemonic '%s' language detection returned wrong error (%v)", currTest.Mnemonic, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Mnemonic '%s' language detection returned error: %s", currTest.Mnemonic, err.Error())
+		} else if lang != currTest.Lang {
+			t.Errorf("Mnemonic '%s' language detection was incorrect: expected %d, got: %d", currTest.Mnemonic, currTest.Lang, lang)
+		}
+	}
+	for _, currTest := range testVect {
+		lang, err := DetectLanguage(currTest.Mnemonic)
+		if err != nil || lang != LangEnglish {
+			t.Errorf("Mnemonic '%s' language detection was incorrect: expected %d, got: %d (%v)", currTest.Mnemonic, LangEnglish, lang, err)
+		}
+	}
+
+	// Words of no language or mixed languages
+	for _, testStr := range testVectDetectLanguageInvalid {
+		_, err := DetectLanguage(testStr)
+		if !errors.Is(err, ErrLanguageNotDetected) {
+			t.Errorf("Mnemonic '%s' language detection returned wrong error (%v)", testStr, err)
+		}
+	}
+}
+
+// Test entropy with language detection
+func TestToEntropyDetect(t *testing.T) {
+	for _, currTest := range testVectLang {
+		// Use English as mnemonic language, it shall be ignored
+		entropy, lang, err := MnemonicFromStringLang(currTest.Mnemonic, LangEnglish).ToEntropyDetect()
+		// Ambiguous mnemonics shall not be detected
+		if isAmbiguousMnemonic(currTest.Mnemonic) {
+			if !errors.Is(err, ErrLanguageNotDetected) {
+				t.Errorf("Ambiguous mnemonic '%s' entropy with detection returned wrong error (%v)", currTest.Mnemonic, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Mnemonic '%s' entropy with detection returned error: %s", currTest.Mnemonic, err.Error())
+			continue
+		}
+		if lang != currTest.Lang {
+			t.Errorf("Mnemonic '%s' detected language was incorrect: expected %s, got: %s", currTest.Mnemonic, currTest.Lang, lang)
+		}
+		if hex.EncodeToString(entropy) != currTest.Entropy {
+			t.Errorf("Mnemonic '%s' entropy with detection was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Entropy, entropy)
+		}
+	}
+
+	// Words of no language or mixed languages
+	for _, testStr := range testVectDetectLanguageInvalid {
+		if _, _, err := MnemonicFromString(testStr).ToEntropyDetect(); !errors.Is(err, ErrLanguageNotDetected) {
+			t.Errorf("Mnemonic '%s' entropy with detection returned wrong error (%v)", testStr, err)
+		}
+	}
+	// Valid words but invalid checksum
+	if _, _, err := MnemonicFromString("legal winner thank year wave sausage worth useful legal winner thank thank").ToEntropyDetect(); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Mnemonic with invalid checksum entropy with detection returned wrong error (%v)", err)
+	}
+}
+
+// Test mnemonic import
+func TestImportMnemonic(t *testing.T) {
+	for _, currTest := range testVectLang {
+		// Uppercase, composed form and extra whitespaces shall be accepted
+		testStr := " " + strings.ToUpper(norm.NFC.String(currTest.Mnemonic)) + "\t\n"
+		mnemonic, lang, err := ImportMnemonic(strings.Replace(testStr, " ", "  ", -1))
+		// Ambiguous mnemonics shall not be imported
+		if isAmbiguousMnemonic(currTest.Mnemonic) {
+			if !errors.Is(err, ErrLanguageNotDetected) {
+				t.Errorf("Ambiguous mnemonic '%s' import returned wrong error (%v)", currTest.Mnemonic, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Mnemonic '%s' import returned error: %s", currTest.Mnemonic, err.Error())
+			continue
+		}
+		if lang != currTest.Lang {
+			t.Errorf("Mnemonic '%s' imported language was incorrect: expected %s, got: %s", currTest.Mnemonic, currTest.Lang, lang)
+		}
+		if mnemonic.Words != currTest.Mnemonic {
+			t.Errorf("Mnemonic import was incorrect: expected %s, got: %s", currTest.Mnemonic, mnemonic.Words)
+		}
+		// Imported mnemonic shall be ready to use
+		if err := mnemonic.ValidateStrict(lang); err != nil {
+			t.Errorf("Imported mnemonic '%s' strict validation returned error: %s", mnemonic.Words, err.Error())
+		}
+	}
+
+	// Empty string, words of no language or mixed languages
+	for _, testStr := range append([]string { "", " \n" }, testVectDetectLanguageInvalid...) {
+		if _, _, err := ImportMnemonic(testStr); !errors.Is(err, ErrLanguageNotDetected) {
+			t.Errorf("Mnemonic '%s' import returned wrong error (%v)", testStr, err)
+		}
+	}
+	// Valid words but invalid checksum
+	if _, _, err := ImportMnemonic("legal winner thank year wave sausage worth useful legal winner thank thank"); !errors.Is(err, ErrChecksum) {
+		t.Errorf("Mnemonic with invalid checksum import returned wrong error (%v)", err)
+	}
+	// Valid words but invalid words number
+	if _, _, err := ImportMnemonic("legal winner thank year wave sausage worth useful legal winner thank"); !errors.Is(err, ErrWordsNum) {
+		t.Errorf("Mnemonic with invalid words number import returned wrong error (%v)", err)
+	}
+}
+
+// Test ambiguous language detection
+func TestDetectLanguageAmbiguous(t *testing.T) {
+	for _, testStr := range testVectDetectLanguageAmbiguous {
+		// Shall be valid in both Chinese languages
+		for _, lang := range []Language { LangChineseSimplified, LangChineseTraditional } {
+			wordsList, _ := getWordsList(lang)
+			_, err := MnemonicFromStringRaw(testStr).toEntropy(wordsList.wordIndex)
+			if err != nil {
+				t.Errorf("Mnemonic '%s' is not valid for language %d: %s", testStr, lang, err.Error())
+			}
+		}
+
+		_, err := DetectLanguage(testStr)
+		if !errors.Is(err, ErrLanguageNotDetected) {
+			t.Errorf("Ambiguous mnemonic '%s' language detection returned wrong error (%v)", testStr, err)
+		}
+	}
+}
+
+// Test words iteration
+func TestEachWord(t *testing.T) {
+	for _, testLang := range SupportedLanguages() {
+		wordsList, _ := getWordsList(testLang)
+
+		// Full iteration, in order
+		count := 0
+		err := EachWord(testLang, func(index int, word string) bool {
+			if index != count || word != wordsList.words[index] {
+				t.Errorf("Language %s word iteration was incorrect at %d: got: %d %s", testLang, count, index, word)
+			}
+			count++
+			return true
+		})
+		if err != nil {
+			t.Errorf("Language %s words iteration returned error: %s", testLang, err.Error())
+		} else if count != wordsListLen {
+			t.Errorf("Language %s words iteration count was incorrect: expected %d, got: %d", testLang, wordsListLen, count)
+		}
+
+		// Early termination
+		for _, stopIdx := range []int { 0, 10, wordsListLen - 1 } {
+			count = 0
+			EachWord(testLang, func(index int, word string) bool {
+				count++
+				return index != stopIdx
+			})
+			if count != stopIdx + 1 {
+				t.Errorf("Language %s words iteration stopped at %d was incorrect: expected %d, got: %d", testLang, stopIdx, stopIdx + 1, count)
+			}
+		}
+	}
+
+	// Unsupported language
+	called := false
+	err := EachWord(Language(-1), func(index int, word string) bool {
+		called = true
+		return true
+	})
+	if called || !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Words iteration with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test language parsing
+func TestParseLanguage(t *testing.T) {
+	// Round-trip of every supported language name
+	for _, testLang := range SupportedLanguages() {
+		for _, testStr := range []string { testLang.String(), strings.ToUpper(testLang.String()), " " + strings.Replace(testLang.String(), "_", "-", -1) + "\n" } {
+			lang, err := ParseLanguage(testStr)
+			if err != nil {
+				t.Errorf("Language '%s' parsing returned error: %s", testStr, err.Error())
+			} else if lang != testLang {
+				t.Errorf("Language '%s' parsing was incorrect: expected %s, got: %s", testStr, testLang, lang)
+			}
+		}
+	}
+
+	// Codes
+	testCodes := map[string]Language {
+		"en":    LangEnglish,
+		"ES":    LangSpanish,
+		"fr":    LangFrench,
+		"ko":    LangKorean,
+		"cs":    LangCzech,
+		"zh-cn": LangChineseSimplified,
+		"zh_TW": LangChineseTraditional,
+	}
+	for testStr, expLang := range testCodes {
+		if lang, err := ParseLanguage(testStr); err != nil || lang != expLang {
+			t.Errorf("Language code '%s' parsing was incorrect: expected %s, got: %s (%v)", testStr, expLang, lang, err)
+		}
+	}
+	// Every supported language shall have a code
+	for _, testLang := range SupportedLanguages() {
+		found := false
+		for _, lang := range languageCodesMap {
+			found = found || lang == testLang
+		}
+		if !found {
+			t.Errorf("Language %s has no code", testLang)
+		}
+	}
+
+	// Unknown or unsupported languages
+	for _, testStr := range []string { "", "unknown", "italian", "it", "japanese", "ja", "chinese", "eng" } {
+		if _, err := ParseLanguage(testStr); !errors.Is(err, ErrUnsupportedLanguage) {
+			t.Errorf("Language '%s' parsing returned wrong error (%v)", testStr, err)
+		}
+	}
+}
+
+// Test mnemonic language
+func TestMnemonicLanguage(t *testing.T) {
+	for _, currTest := range testVectLang {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+		mnemonic, _ := MnemonicFromEntropyLang(entropy, currTest.Lang)
+		if mnemonic.Language() != currTest.Lang {
+			t.Errorf("Mnemonic from entropy language was incorrect: expected %s, got: %s", currTest.Lang, mnemonic.Language())
+		}
+		if mnemonic = MnemonicFromStringLang(currTest.Mnemonic, currTest.Lang); mnemonic.Language() != currTest.Lang {
+			t.Errorf("Mnemonic from string language was incorrect: expected %s, got: %s", currTest.Lang, mnemonic.Language())
+		}
+	}
+
+	// Default language
+	if lang := MnemonicFromStringRaw(testVect[0].Mnemonic).Language(); lang != LangEnglish {
+		t.Errorf("Raw mnemonic language was incorrect: expected %s, got: %s", LangEnglish, lang)
+	}
+}
+
+// Test mnemonic language breakdown
+func TestMnemonicLanguageBreakdown(t *testing.T) {
+	// Single language mnemonics shall have all the words in their language
+	for _, currTest := range testVectLang {
+		breakdown := MnemonicFromString(currTest.Mnemonic).LanguageBreakdown()
+		wordsNum := len(strings.Fields(currTest.Mnemonic))
+		if len(breakdown[currTest.Lang]) != wordsNum {
+			t.Errorf("Mnemonic '%s' language breakdown was incorrect: expected %d words in %s, got: %v", currTest.Mnemonic, wordsNum, currTest.Lang, breakdown)
+		}
+		if _, ok := breakdown[LangUnknown]; ok {
+			t.Errorf("Mnemonic '%s' language breakdown contained unknown words: %v", currTest.Mnemonic, breakdown)
+		}
+	}
+
+	// Mixed languages, with words in more languages and in no language
+	breakdown := MnemonicFromStringRaw(" Legal \u00e1baco abandon zzzx abeceda\t\u7684 winner ").LanguageBreakdown()
+	expBreakdown := map[Language][]int {
+		LangEnglish            : []int { 0, 2, 6 },
+		LangSpanish            : []int { 1 },
+		LangFrench             : []int { 2 },
+		LangCzech              : []int { 4 },
+		LangChineseSimplified  : []int { 5 },
+		LangChineseTraditional : []int { 5 },
+		LangUnknown            : []int { 3 },
+	}
+	if !reflect.DeepEqual(breakdown, expBreakdown) {
+		t.Errorf("Mixed mnemonic language breakdown was incorrect: expected %v, got: %v", expBreakdown, breakdown)
+	}
+
+	// Empty mnemonic
+	if breakdown = MnemonicFromStringRaw("").LanguageBreakdown(); len(breakdown) != 0 {
+		t.Errorf("Empty mnemonic language breakdown was not empty: got: %v", breakdown)
+	}
+
+	// Unknown language shall not be supported
+	if LangUnknown.String() != "unknown" {
+		t.Errorf("Unknown language name was incorrect: expected unknown, got: %s", LangUnknown.String())
+	}
+	if _, err := getWordsList(LangUnknown); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Unknown language returned wrong error (%v)", err)
+	}
+}
+
+// Test unsupported language
+func TestLanguageUnsupported(t *testing.T) {
+	entropy, _ := hex.DecodeString(testVect[0].Entropy)
+
+	if _, err := MnemonicFromEntropyLang(entropy, Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Mnemonic from entropy with unsupported language returned wrong error (%v)", err)
+	}
+	if _, err := MnemonicFromWordsNumLang(WordsNum12, Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Mnemonic from words number with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Get if the specified mnemonic is ambiguous.
+func isAmbiguousMnemonic(mnemonic string) bool {
+	for _, testStr := range testVectDetectLanguageAmbiguous {
+		if testStr == mnemonic {
+			return true
+		}
+	}
+	return false
+}
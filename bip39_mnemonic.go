@@ -30,12 +30,18 @@ package bip39
 import (
 	"bytes"
 	"crypto/sha256"
-	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strconv"
+	"io"
+	"math/bits"
 	"strings"
-	"golang.org/x/crypto/pbkdf2"
+	"unicode"
+	"unicode/utf8"
+	"golang.org/x/crypto/ripemd160"
 )
 
 //
@@ -49,8 +55,20 @@ const (
 	WordsNum21 = 21
 	WordsNum24 = 24
 
-	// Word bit length
-	wordBitLen = 11
+	// Fingerprint length in bytes
+	fingerprintLen = 4
+
+	// Separator between words
+	wordsSeparator = " "
+	// Placeholder for redacted words
+	redactedWord = "****"
+
+	// Bit length of a word, i.e. of its index in the words list
+	WordBitLen = 11
+	// Word bit mask
+	wordBitMask = (1 << WordBitLen) - 1
+	// Words list length
+	wordsListLen = 1 << WordBitLen
 
 	// Modified for seed salt
 	seedSaltMod = "mnemonic"
@@ -70,6 +88,25 @@ var (
 	ErrInvalidWord = errors.New("The mnemonic contains an invalid word")
 	// ErrChecksum is returned when trying to get entropy or validating a mnemonic with invalid checksum
 	ErrChecksum = errors.New("The checksum of the mnemonic is not valid")
+	// ErrUnexpectedWordsNum is returned when validating a mnemonic whose words number is different from the expected one
+	ErrUnexpectedWordsNum = errors.New("The mnemonic words number is different from the expected one")
+	// ErrNonCanonicalForm is returned when validating in strict mode a mnemonic that is not in canonical form
+	ErrNonCanonicalForm = errors.New("The mnemonic is not in canonical form")
+	// ErrWeakEntropy is returned when trying to generate mnemonic from weak entropy (e.g. all zeros)
+	ErrWeakEntropy = errors.New("The entropy is too weak for generating a mnemonic")
+	// ErrWordIndex is returned when trying to create a mnemonic from a word index that is out of range
+	ErrWordIndex = errors.New("The word index is not valid")
+	// ErrInvalidWordlistLen is returned when trying to use a custom words list with invalid length
+	ErrInvalidWordlistLen = errors.New("The words list shall contain exactly 2048 words")
+	// ErrSeedRounds is returned when trying to generate seed with a number of rounds that is not positive
+	ErrSeedRounds = errors.New("The number of rounds for seed generation shall be positive")
+	// ErrMnemonicsCount is returned when trying to generate a number of mnemonics that is not positive
+	ErrMnemonicsCount = errors.New("The number of mnemonics to generate shall be positive")
+	// ErrEntropyTooShort is returned when trying to generate mnemonic from entropy shorter than required by the words number
+	ErrEntropyTooShort = errors.New("The entropy is too short for the specified words number")
+
+	// Table of ASCII whitespaces (same of unicode.IsSpace)
+	asciiSpace = [utf8.RuneSelf]uint8 { '\t': 1, '\n': 1, '\v': 1, '\f': 1, '\r': 1, ' ': 1 }
 
 	// Helper map for checking words number validity
 	wordsNumMap = map[int]bool {
@@ -85,118 +122,821 @@ var (
 // Types
 //
 
-// Structure for mnemonic
+// Structure for mnemonic.
+// All methods only read the mnemonic and keep no internal state, so they are safe for concurrent use as long as
+// no goroutine modifies Words (or unmarshals into the mnemonic) at the same time.
+// Use Clone for getting an independent copy that can be freely modified.
 type Mnemonic struct {
 	Words string
+	// Language of the words (English by default)
+	lang Language
+	// String the mnemonic was created from before normalization (empty if not normalized), for strict validation
+	raw string
+}
+
+// Structure for the information about a mnemonic word, see Mnemonic.Breakdown
+type WordInfo struct {
+	// Word as in the words list
+	Word string
+	// Index of the word in the words list
+	Index int
+	// Index as 11-bit binary string
+	Bits string
+	// True for the word containing the checksum bits (i.e. the last one), which begin after its first LastWordEntropyBits bits
+	IsChecksumBoundary bool
 }
 
 //
 // Exported functions
 //
 
-// Generate mnemonic from the specified words number.
+// Generate mnemonic from the specified words number, using the English words list.
 // A random entropy is used for generating mnemonic.
 func MnemonicFromWordsNum(wordsNum int) (*Mnemonic, error) {
+	return MnemonicFromWordsNumLang(wordsNum, LangEnglish)
+}
+
+// Generate mnemonic from the specified words number, using the words list of the specified language.
+// A random entropy is used for generating mnemonic.
+func MnemonicFromWordsNumLang(wordsNum int, lang Language) (*Mnemonic, error) {
 	// Validate words number
 	err := validateWordsNum(wordsNum)
 	if err != nil {
 		return nil, err
 	}
+	// Validate language
+	_, err = getWordsList(lang)
+	if err != nil {
+		return nil, err
+	}
 
-	// Get entropy bit length from words number
-	entropyBitLen := (wordsNum * 11) - (wordsNum / 3)
 	// Generate entropy
-	entropy, _ := GenerateEntropy(entropyBitLen)
+	entropyBuff := getEntropyBuffer(wordsNumToEntropyBitLen(wordsNum) / 8)
+	entropy := *entropyBuff
+	err = readRandom(entropy)
+	// Wipe it and put it back to the pool once the mnemonic is generated, since it's not returned
+	defer putEntropyBuffer(entropyBuff)
+	if err != nil {
+		return nil, err
+	}
 
 	// Generate mnemonic from entropy
-	return MnemonicFromEntropy(entropy)
+	return MnemonicFromEntropyLang(entropy, lang)
+}
+
+// Generate mnemonic from the specified words number, using the words list of the specified language and starting with the specified words.
+// The remaining words are random, except the last one that contains the checksum.
+// For this reason, the prefix words shall be less than the words number.
+// The prefix words are normalized like the words of MnemonicFromString (e.g. uppercase letters and composed accents are accepted).
+// Error is returned if words number, prefix words or language is not valid.
+func MnemonicFromWordsNumWithPrefix(wordsNum int, prefixWords []string, lang Language) (*Mnemonic, error) {
+	// Validate words number
+	err := validateWordsNum(wordsNum)
+	if err != nil {
+		return nil, err
+	}
+	if len(prefixWords) >= wordsNum {
+		return nil, fmt.Errorf("prefix words number %d (mnemonic words number %d): %w", len(prefixWords), wordsNum, ErrWordsNum)
+	}
+	// Get words list
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the index of each prefix word, normalized like the words of MnemonicFromString
+	prefixIdx := make([]int, 0, len(prefixWords))
+	for i, word := range prefixWords {
+		wordIdx := wordsList.wordIndex(NormalizeMnemonic(word))
+		if wordIdx == -1 {
+			return nil, &WordError { Index: i, Word: word }
+		}
+		prefixIdx = append(prefixIdx, wordIdx)
+	}
+
+	// Generate a random mnemonic and get its word indexes
+	entropyBuff := getEntropyBuffer(wordsNumToEntropyBitLen(wordsNum) / 8)
+	entropy := *entropyBuff
+	defer putEntropyBuffer(entropyBuff)
+	err = readRandom(entropy)
+	if err != nil {
+		return nil, err
+	}
+	mnemonic, err := MnemonicFromEntropyLang(entropy, lang)
+	if err != nil {
+		return nil, err
+	}
+	wordsIdx, _ := mnemonic.getWordIndexes(wordsList.wordIndex)
+
+	// Replace the leading words, which only contain entropy bits since they are less than the words number,
+	// then compute the mnemonic again for getting the correct checksum
+	copy(wordsIdx, prefixIdx)
+	prefixEntropy, _ := wordIndexesToEntropyAndChecksum(wordsIdx)
+	defer Wipe(prefixEntropy)
+
+	return MnemonicFromEntropyLang(prefixEntropy, lang)
+}
+
+// Generate mnemonic from the specified words number, using the words list of the specified language and starting with the specified word.
+// It's a shortcut of MnemonicFromWordsNumWithPrefix with a single prefix word, e.g. for labeled test wallets.
+// Error is returned if words number, first word or language is not valid.
+func MnemonicWithFirstWord(firstWord string, wordsNum int, lang Language) (*Mnemonic, error) {
+	return MnemonicFromWordsNumWithPrefix(wordsNum, []string { firstWord }, lang)
+}
+
+// Generate the specified number of independent mnemonics with the specified words number, using the words list of the specified language.
+// The entropy of all the mnemonics is read at once from the random generator, then split between them.
+// Error is returned if the number of mnemonics, the words number or the language is not valid, or if the random generator fails.
+func GenerateMnemonics(count int, wordsNum int, lang Language) ([]*Mnemonic, error) {
+	// Validate parameters
+	if count <= 0 {
+		return nil, fmt.Errorf("count %d: %w", count, ErrMnemonicsCount)
+	}
+	err := validateWordsNum(wordsNum)
+	if err != nil {
+		return nil, err
+	}
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate entropy for all mnemonics
+	entropyLen := wordsNumToEntropyBitLen(wordsNum) / 8
+	entropyBuff := getEntropyBuffer(count * entropyLen)
+	entropy := *entropyBuff
+	defer putEntropyBuffer(entropyBuff)
+	err = readRandom(entropy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate mnemonics
+	mnemonics := make([]*Mnemonic, 0, count)
+	for i := 0; i < len(entropy); i += entropyLen {
+		mnemonics = append(mnemonics, &Mnemonic {
+			Words: entropyToWords(entropy[i: i + entropyLen], wordsList.words),
+			lang:  lang,
+		})
+	}
+
+	return mnemonics, nil
 }
 
-// Generate mnemonic from the specific entropy.
+// Generate mnemonic from the specific entropy, using the English words list.
 // The entropy slice shall be of a valid length.
 func MnemonicFromEntropy(entropy []byte) (*Mnemonic, error) {
+	return MnemonicFromEntropyLang(entropy, LangEnglish)
+}
+
+// Generate mnemonic from the specific entropy, using the words list of the specified language.
+// The entropy slice shall be of a valid length.
+func MnemonicFromEntropyLang(entropy []byte, lang Language) (*Mnemonic, error) {
 	// Validate entropy bit length
 	err := validateEntropyBitLen(len(entropy) * 8)
 	if err != nil {
 		return nil, err
 	}
+	// Get words list
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mnemonic {
+		Words: entropyToWords(entropy, wordsList.words),
+		lang:  lang,
+	}, nil
+}
+
+// Generate mnemonic from the specific entropy encoded as hex string, using the words list of the specified language.
+// The hex decoding error (e.g. hex.ErrLength for odd-length strings) is returned wrapped if the string is not valid.
+func MnemonicFromEntropyHex(hexStr string, lang Language) (*Mnemonic, error) {
+	// Decode entropy
+	entropy, err := hex.DecodeString(hexStr)
+	if err != nil {
+		return nil, fmt.Errorf("entropy hex string: %w", err)
+	}
+	defer Wipe(entropy)
+
+	return MnemonicFromEntropyLang(entropy, lang)
+}
 
-	// Convert entropy to binary string
-	entropyBinStr := bytesToBinaryString(entropy)
-	// Compute checksum as binary string
-	chksumBinStr := entropyChecksumBinStr(entropy)
-	// Append it to entropy
-	mnemonicBinStr := entropyBinStr + chksumBinStr
+// Generate mnemonic from the specific entropy, using the English words list, like MnemonicFromEntropy.
+// Differently from MnemonicFromEntropy, weak entropy (i.e. all bytes equal, like all zeros or all 0xFF) is rejected with ErrWeakEntropy.
+// This guards against generating a well-known mnemonic (e.g. "abandon abandon ... about") when the caller's random generator failed.
+func MnemonicFromEntropyChecked(entropy []byte) (*Mnemonic, error) {
+	// Validate entropy bit length
+	err := validateEntropyBitLen(len(entropy) * 8)
+	if err != nil {
+		return nil, err
+	}
+	// Validate entropy strength
+	if isWeakEntropy(entropy) {
+		return nil, ErrWeakEntropy
+	}
 
-	// Create slice for mnemonic
-	mnemonicLen := len(mnemonicBinStr) / wordBitLen
-	mnemonic := make([]string, 0, mnemonicLen)
+	return MnemonicFromEntropy(entropy)
+}
 
-	// Split binary string in groups of 11-bit and map them to the words list
-	for i := 0; i < mnemonicLen; i++ {
-		// Get current word binary string
-		wordStrBin := mnemonicBinStr[i * wordBitLen: (i + 1) * wordBitLen]
-		// Convert to integer
-		wordIdx, _ := strconv.ParseInt(wordStrBin, 2, 16)
-		// Append the correspondent word
-		mnemonic = append(mnemonic, wordsListEn[wordIdx])
+// Generate mnemonic from the specific entropy using a custom words list.
+// The entropy slice shall be of a valid length and the words list shall contain exactly 2048 different words, not necessarily sorted.
+// Error is returned if the words list contains duplicates (ErrDuplicateWord), since the mnemonic couldn't be converted back.
+func MnemonicFromEntropyCustom(entropy []byte, wordlist []string) (*Mnemonic, error) {
+	// Validate words list
+	_, err := customWordsIdxMap(wordlist)
+	if err != nil {
+		return nil, err
+	}
+	// Validate entropy bit length
+	err = validateEntropyBitLen(len(entropy) * 8)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Mnemonic {
-		Words: strings.Join(mnemonic, " "),
+		Words: entropyToWords(entropy, wordlist),
 	}, nil
 }
 
+// Generate mnemonic with the specified words number from the specific entropy, using the words list of the specified language.
+// The entropy can be longer than required (e.g. a 32-byte source for a 12-word mnemonic): only its first bytes are used.
+// Error is returned if the words number or language is not valid, or if the entropy is too short.
+func MnemonicFromEntropyTruncated(entropy []byte, wordsNum int, lang Language) (*Mnemonic, error) {
+	// Validate words number
+	err := validateWordsNum(wordsNum)
+	if err != nil {
+		return nil, err
+	}
+	// Validate entropy length
+	entropyLen := wordsNumToEntropyBitLen(wordsNum) / 8
+	if len(entropy) < entropyLen {
+		return nil, fmt.Errorf("entropy length %d, required %d: %w", len(entropy), entropyLen, ErrEntropyTooShort)
+	}
+
+	return MnemonicFromEntropyLang(entropy[:entropyLen], lang)
+}
+
+// Generate mnemonic deterministically from the specified label, using the English words list.
+// The entropy is the SHA-256 of the label truncated to the specified bit length, which shall be valid.
+// WARNING: the entropy is as weak as the label, so it shall NOT be used for real wallets but only for tests (e.g. reproducible fixtures).
+func MnemonicFromDeterministicSeed(label string, bitLen int) (*Mnemonic, error) {
+	// Validate entropy bit length
+	err := validateEntropyBitLen(bitLen)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compute entropy from label
+	hash := sha256.Sum256([]byte(label))
+	defer Wipe(hash[:])
+
+	return MnemonicFromEntropy(hash[:bitLen / 8])
+}
+
 // Create mnemonic object from a mnemonic string.
+// The string is normalized by NormalizeMnemonic, so leading, trailing and multiple whitespaces and uppercase letters are accepted.
+// The language is detected by DetectLanguage as a best effort: if it cannot be detected (e.g. invalid words), English is used.
+// Use MnemonicFromStringLang if the language is known, to avoid any ambiguity.
 func MnemonicFromString(mnemonic string) (*Mnemonic) {
+	lang, err := DetectLanguage(mnemonic)
+	if err != nil {
+		lang = LangEnglish
+	}
+	return MnemonicFromStringLang(mnemonic, lang)
+}
+
+// Create mnemonic object from a mnemonic string in the specified language.
+// The string is normalized by NormalizeMnemonic, as for MnemonicFromString.
+// The words list of the language is used by the subsequent operations (e.g. Validate, ToEntropy), which return ErrUnsupportedLanguage if it's not supported.
+func MnemonicFromStringLang(mnemonic string, lang Language) (*Mnemonic) {
+	return &Mnemonic {
+		Words: NormalizeMnemonic(mnemonic),
+		lang:  lang,
+		raw:   mnemonic,
+	}
+}
+
+// Create mnemonic object from a mnemonic string, without normalizing it.
+// The string is kept exactly as it is.
+func MnemonicFromStringRaw(mnemonic string) (*Mnemonic) {
 	return &Mnemonic {
 		Words: mnemonic,
 	}
 }
 
+// Import a mnemonic string, e.g. typed or pasted by a user, returning it in canonical form together with its language.
+// The string is normalized (whitespaces, case and Unicode form), its language is detected by DetectLanguage and its checksum is validated.
+// The returned mnemonic words are exactly as in the words list of the detected language, separated by single spaces.
+// Error is returned if the language cannot be detected or the mnemonic is not valid.
+func ImportMnemonic(raw string) (*Mnemonic, Language, error) {
+	// Get entropy, detecting language
+	entropy, lang, err := MnemonicFromStringRaw(normalizeNfkd(NormalizeMnemonic(raw))).ToEntropyDetect()
+	if err != nil {
+		return nil, 0, err
+	}
+	defer Wipe(entropy)
+
+	// Build the mnemonic again from the entropy, so that the words are in canonical form
+	mnemonic, err := MnemonicFromEntropyLang(entropy, lang)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return mnemonic, lang, nil
+}
+
+// Normalize a mnemonic string.
+// The string is converted to lowercase and its whitespaces are collapsed into single spaces, removing leading and trailing ones.
+func NormalizeMnemonic(mnemonic string) string {
+	return collapseWhitespaces(strings.ToLower(mnemonic))
+}
+
+// Get the entropy bit length corresponding to the specified words number (e.g. 12 words = 128 bits).
+// Error is returned if the words number is not valid.
+func EntropyBitLenFromWordsNum(wordsNum int) (int, error) {
+	// Validate words number
+	err := validateWordsNum(wordsNum)
+	if err != nil {
+		return 0, err
+	}
+
+	return wordsNumToEntropyBitLen(wordsNum), nil
+}
+
+// Get the words number corresponding to the specified entropy bit length (e.g. 128 bits = 12 words).
+// Error is returned if the entropy bit length is not valid.
+func WordsNumFromEntropyBitLen(bitLen int) (int, error) {
+	// Validate entropy bit length
+	err := validateEntropyBitLen(bitLen)
+	if err != nil {
+		return 0, err
+	}
+
+	return entropyBitLenToWordsNum(bitLen), nil
+}
+
+// Get the words number corresponding to the specified total bit length, i.e. entropy plus checksum (e.g. 132 bits = 12 words).
+// Valid bit lengths are 132, 165, 198, 231 and 264, i.e. words number * WordBitLen.
+// Error is returned if the total bit length is not valid.
+func WordsNumFromBits(totalBits int) (int, error) {
+	if totalBits % WordBitLen != 0 || validateWordsNum(totalBits / WordBitLen) != nil {
+		return 0, fmt.Errorf("total bit length %d: %w", totalBits, ErrWordsNum)
+	}
+	return totalBits / WordBitLen, nil
+}
+
+// Get the checksum bit length for the specified entropy bit length (i.e. one bit every 32 bits of entropy).
+// The entropy bit length is not validated, so the result is meaningful only for valid lengths.
+func ChecksumBitLen(entropyBits int) int {
+	return entropyBits / 32
+}
+
+// Get the number of bits of the last word that are free entropy (the remaining ones are checksum) for the specified words number.
+// The number of valid last words for a given mnemonic prefix is 2^LastWordEntropyBits (e.g. 128 for 12 words).
+// Zero is returned if the words number is not valid.
+func LastWordEntropyBits(wordsNum int) int {
+	if validateWordsNum(wordsNum) != nil {
+		return 0
+	}
+	return WordBitLen - ChecksumBitLen(wordsNumToEntropyBitLen(wordsNum))
+}
+
+// Get the last word of the mnemonic of the specified entropy, using the words list of the specified language.
+// The entropy shall be the full one (e.g. 128 bits for 12 words): the last word contains both its last entropy bits and the checksum,
+// so it's determined by the whole entropy. It's the same of the last word of MnemonicFromEntropyLang, without building the mnemonic.
+// Error is returned if the entropy bit length or the language is not valid.
+func ChecksumWord(entropy []byte, lang Language) (string, error) {
+	// Validate entropy bit length
+	err := validateEntropyBitLen(len(entropy) * 8)
+	if err != nil {
+		return "", err
+	}
+	// Get words list
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return "", err
+	}
+
+	wordsIdx := entropyToWordIndexes(entropy)
+	return wordsList.words[wordsIdx[len(wordsIdx) - 1]], nil
+}
+
+// Get the number of bits of the key space of mnemonics with the specified words number, i.e. there are 2^bits possible mnemonics.
+// It's the entropy bit length, since the checksum is derived from the entropy (so it's NOT words number * 11).
+// Error is returned if the words number is not valid.
+func MnemonicSpaceBits(wordsNum int) (int, error) {
+	return EntropyBitLenFromWordsNum(wordsNum)
+}
+
+// Get the checksum of the specified entropy, returning the checksum bytes and their bit length.
+// The checksum bits are aligned to the least significant bits of the last byte.
+// Error is returned if the entropy bit length is not valid.
+func EntropyChecksum(entropy []byte) ([]byte, int, error) {
+	// Validate entropy bit length
+	err := validateEntropyBitLen(len(entropy) * 8)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return []byte { entropyChecksum(entropy) }, entropyChecksumBitLen(entropy), nil
+}
+
+// Validate a batch of mnemonic strings using the words list of the specified language.
+// The strings are normalized by NormalizeMnemonic and the words list is looked up only once, without detecting the language of each string,
+// so it is faster than validating them one at a time.
+// The returned slice contains an error for each mnemonic, in the same order (nil if valid).
+func ValidateBatch(mnemonics []string, lang Language) []error {
+	errs := make([]error, len(mnemonics))
+
+	// Get words list
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	// Validate each mnemonic
+	for i, mnemonicStr := range mnemonics {
+		entropy, err := MnemonicFromStringLang(mnemonicStr, lang).toEntropy(wordsList.wordIndex)
+		Wipe(entropy)
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// Create mnemonic from the specified word indexes (e.g. entered on a hardware device), using the words list of the specified language.
+// Each index shall be in the range [0, 2047] and the checksum encoded by the last index shall be valid.
+// Error is returned if words number, indexes, checksum or language is not valid.
+func MnemonicFromWordIndices(indices []int, lang Language) (*Mnemonic, error) {
+	// Get words list
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return nil, err
+	}
+	// Validate indexes and checksum
+	entropy, err := IndicesToEntropy(indices)
+	if err != nil {
+		return nil, err
+	}
+	Wipe(entropy)
+
+	// Map each index to the words list
+	words := make([]string, 0, len(indices))
+	for _, wordIdx := range indices {
+		words = append(words, wordsList.words[wordIdx])
+	}
+
+	return &Mnemonic {
+		Words: strings.Join(words, wordsSeparator),
+		lang:  lang,
+	}, nil
+}
+
+// Get the word indexes (i.e. the 11-bit groups, including the checksum) of the specified entropy, independently from the language.
+// Error is returned if the entropy bit length is not valid.
+func EntropyToIndices(entropy []byte) ([]int, error) {
+	// Validate entropy bit length
+	err := validateEntropyBitLen(len(entropy) * 8)
+	if err != nil {
+		return nil, err
+	}
+
+	return entropyToWordIndexes(entropy), nil
+}
+
+// Get the entropy from the specified word indexes (i.e. the 11-bit groups, including the checksum), independently from the language.
+// Each index shall be in the range [0, 2047] and the checksum encoded by the last index shall be valid.
+// Error is returned if words number, indexes or checksum is not valid.
+func IndicesToEntropy(indices []int) ([]byte, error) {
+	// Validate words number
+	err := validateWordsNum(len(indices))
+	if err != nil {
+		return nil, err
+	}
+	// Validate indexes
+	for i, wordIdx := range indices {
+		if wordIdx < 0 || wordIdx >= wordsListLen {
+			return nil, fmt.Errorf("word index %d at position %d: %w", wordIdx, i, ErrWordIndex)
+		}
+	}
+
+	// Verify checksum
+	entropy, chksum := wordIndexesToEntropyAndChecksum(indices)
+	if expChksum := entropyChecksum(entropy); expChksum != chksum {
+		Wipe(entropy)
+		return nil, newChecksumError(chksum, expChksum, len(indices) / 3)
+	}
+
+	return entropy, nil
+}
+
 // Convert a mnemonic back to entropy bytes.
 // Error is returned if mnemonic or checksum is not valid.
 func (mnemonic *Mnemonic) ToEntropy() ([]byte, error) {
-	// Get binary strings from mnemonic
-	entropyBinStr, chksumBinStr, err := mnemonic.getBinaryStrings()
+	// Get words list
+	wordsList, err := getWordsList(mnemonic.lang)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get entropy bytes
-	entropy, _ := binaryStringToBytes(entropyBinStr)
-	// Compute checksum
-	chksumComp := entropyChecksumBinStr(entropy)
+	return mnemonic.toEntropy(wordsList.wordIndex)
+}
+
+// Get back the entropy from the mnemonic, detecting its language by DetectLanguage instead of using the mnemonic one.
+// The detected language is returned together with the entropy, e.g. for import flows where no language hint is available.
+// ErrLanguageNotDetected is returned if the language cannot be uniquely detected.
+func (mnemonic *Mnemonic) ToEntropyDetect() ([]byte, Language, error) {
+	// Detect language
+	lang, err := DetectLanguage(mnemonic.Words)
+	if err != nil {
+		return nil, 0, err
+	}
+	// Get entropy
+	entropy, err := mnemonic.toEntropy(languageWordsListMap[lang].wordIndex)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entropy, lang, nil
+}
+
+// Derive a mnemonic with more words from a mnemonic, using the words list of the specified language.
+// The entropy is extended by appending the SHA-256 of the original entropy and truncating it to the target words number,
+// so the new mnemonic starts with the same entropy bits and it's always the same for the same mnemonic.
+// WARNING: it's a deterministic derivation, NOT real additional entropy: the new mnemonic is exactly as strong as the original one
+// (e.g. 128 bits for 12 words) and anyone knowing the original mnemonic can derive it.
+// Error is returned if the mnemonic, the target words number or the language is not valid, or if the target words number is not greater than the current one.
+func (mnemonic *Mnemonic) ExtendEntropy(targetWordsNum int, lang Language) (*Mnemonic, error) {
+	// Validate target words number
+	err := validateWordsNum(targetWordsNum)
+	if err != nil {
+		return nil, err
+	}
+	// Get entropy
+	entropy, err := mnemonic.ToEntropy()
+	if err != nil {
+		return nil, err
+	}
+	defer Wipe(entropy)
+	if wordsNum := entropyBitLenToWordsNum(len(entropy) * 8); targetWordsNum <= wordsNum {
+		return nil, fmt.Errorf("target words number %d (current %d): %w", targetWordsNum, wordsNum, ErrWordsNum)
+	}
+
+	// Append the entropy hash
+	hash := sha256.Sum256(entropy)
+	defer Wipe(hash[:])
+	extEntropy := append(append(make([]byte, 0, len(entropy) + len(hash)), entropy...), hash[:]...)
+	defer Wipe(extEntropy)
+
+	return MnemonicFromEntropyTruncated(extEntropy, targetWordsNum, lang)
+}
+
+// Convert a mnemonic back to entropy bytes, writing them into the specified buffer and returning the number of written bytes.
+// Differently from ToEntropy, no memory is allocated (except for errors), so the same buffer can be reused for verifying many mnemonics.
+// Error is returned if the buffer is too short (io.ErrShortBuffer) or if mnemonic or checksum is not valid.
+func (mnemonic *Mnemonic) ToEntropyInto(dst []byte) (int, error) {
+	// Get words list
+	wordsList, err := getWordsList(mnemonic.lang)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get the index of each word, using an array for avoiding allocations
+	var wordsIdx [WordsNum24]int
+	wordsNum := 0
+	for word, rest := nextWord(mnemonic.Words); word != ""; word, rest = nextWord(rest) {
+		// Stop if too many words
+		if wordsNum == len(wordsIdx) {
+			wordsNum = countWords(mnemonic.Words)
+			break
+		}
+		wordIdx := wordsList.wordIndex(word)
+		if wordIdx == -1 {
+			return 0, &WordError { Index: wordsNum, Word: word }
+		}
+		wordsIdx[wordsNum] = wordIdx
+		wordsNum++
+	}
+
+	// Validate words number
+	err = validateWordsNum(wordsNum)
+	if err != nil {
+		return 0, err
+	}
+	// Validate buffer length
+	entropyLen := wordsNumToEntropyBitLen(wordsNum) / 8
+	if len(dst) < entropyLen {
+		return 0, fmt.Errorf("buffer length %d (entropy length %d): %w", len(dst), entropyLen, io.ErrShortBuffer)
+	}
+
+	// Shift word indexes into an accumulator and extract 8 bits each time they are available,
+	// the remaining bits at the end are the checksum
+	var acc uint32
+	accBitLen := 0
+	n := 0
+	for _, wordIdx := range wordsIdx[:wordsNum] {
+		acc = (acc << WordBitLen) | uint32(wordIdx)
+		accBitLen += WordBitLen
+		for accBitLen >= 8 && n < entropyLen {
+			accBitLen -= 8
+			dst[n] = byte(acc >> uint(accBitLen))
+			n++
+		}
+	}
 
 	// Compare checksum
-	if chksumComp != chksumBinStr {
-		return nil, ErrChecksum
+	chksum := byte(acc & ((1 << uint(accBitLen)) - 1))
+	if expChksum := entropyChecksum(dst[:n]); expChksum != chksum {
+		Wipe(dst[:n])
+		return 0, newChecksumError(chksum, expChksum, accBitLen)
 	}
 
-	return entropy, nil
+	return n, nil
+}
+
+// Convert a mnemonic back to entropy bytes using a custom words list.
+// The words list shall contain exactly 2048 different words, not necessarily sorted.
+// Error is returned if words list, mnemonic or checksum is not valid.
+func (mnemonic *Mnemonic) ToEntropyCustom(wordlist []string) ([]byte, error) {
+	// Validate words list and build a map for getting word indexes, since the list may not be sorted
+	wordsIdxMap, err := customWordsIdxMap(wordlist)
+	if err != nil {
+		return nil, err
+	}
+
+	return mnemonic.toEntropy(func(word string) int {
+		if wordIdx, ok := wordsIdxMap[word]; ok {
+			return wordIdx
+		}
+		return -1
+	})
+}
+
+// Get the index of each word of a mnemonic in the words list of the specified language.
+// Error is returned if words number, words or language is not valid (the checksum is not verified).
+func (mnemonic *Mnemonic) WordIndices(lang Language) ([]int, error) {
+	// Get words list
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	return mnemonic.getWordIndexes(wordsList.wordIndex)
+}
+
+// Get the breakdown of the mnemonic words using the words list of the specified language, e.g. for visualization purposes.
+// For each word, its index and bits are returned and the word where the checksum bits begin is marked.
+// The checksum is not validated, so the breakdown of a mnemonic with invalid checksum can be got as well.
+// Error is returned if words number, words or language is not valid.
+func (mnemonic *Mnemonic) Breakdown(lang Language) ([]WordInfo, error) {
+	// Get words list
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return nil, err
+	}
+	// Get word indexes
+	wordsIdx, err := mnemonic.getWordIndexes(wordsList.wordIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build the information for each word
+	wordsInfo := make([]WordInfo, 0, len(wordsIdx))
+	for i, wordIdx := range wordsIdx {
+		wordsInfo = append(wordsInfo, WordInfo {
+			Word:               wordsList.words[wordIdx],
+			Index:              wordIdx,
+			Bits:               fmt.Sprintf("%0*b", WordBitLen, wordIdx),
+			IsChecksumBoundary: i == len(wordsIdx) - 1,
+		})
+	}
+
+	return wordsInfo, nil
+}
+
+// Get the number of bits of the checksum embedded in a mnemonic that differ from the expected one.
+// Zero means that the checksum is valid. It can be useful for diagnosing a wrong word.
+// Error is returned if words number or words are not valid.
+func (mnemonic *Mnemonic) ChecksumBitErrors() (int, error) {
+	// Get words list
+	wordsList, err := getWordsList(mnemonic.lang)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get entropy and checksum from mnemonic
+	entropy, chksum, err := mnemonic.getEntropyAndChecksum(wordsList.wordIndex)
+	if err != nil {
+		return 0, err
+	}
+	defer Wipe(entropy)
+
+	// Count the differing bits
+	return bits.OnesCount8(entropyChecksum(entropy) ^ chksum), nil
 }
 
 // Validate a mnemonic.
 // For being valid, all the mnemonic words shall exists in the words list and the checksum shall be valid.
 func (mnemonic *Mnemonic) Validate() error {
-	// Get binary strings from mnemonic
-	entropyBinStr, chksumBinStr, err := mnemonic.getBinaryStrings()
+	_, err := mnemonic.ToEntropy()
+	return err
+}
+
+// Validate only the words of a mnemonic, using the words list of the specified language, without verifying the checksum.
+// The words number shall be valid and all the words shall exist in the words list, otherwise ErrWordsNum or ErrInvalidWord (as WordError) is returned.
+// ErrChecksum is never returned, so it can be used for incremental feedback while the user is typing (e.g. highlighting invalid words).
+func (mnemonic *Mnemonic) ValidateWords(lang Language) error {
+	// Get words list
+	wordsList, err := getWordsList(lang)
 	if err != nil {
 		return err
 	}
 
-	// Get entropy bytes
-	entropy, _ := binaryStringToBytes(entropyBinStr)
-	// Compute checksum
-	chksumComp := entropyChecksumBinStr(entropy)
+	_, err = mnemonic.getWordIndexes(wordsList.wordIndex)
+	return err
+}
 
-	// Compare checksum
-	if chksumComp != chksumBinStr {
-		return ErrChecksum
+// Validate a mnemonic, checking also that it contains the expected number of words (e.g. when restoring a 24-word backup).
+// The words number is checked before anything else, ErrUnexpectedWordsNum is returned if it's different from the expected one.
+// Error is returned if the expected words number is not valid.
+func (mnemonic *Mnemonic) ValidateExpectLen(expected int) error {
+	// Validate expected words number
+	err := validateWordsNum(expected)
+	if err != nil {
+		return err
+	}
+	// Check words number
+	if wordsNum := mnemonic.WordCount(); wordsNum != expected {
+		return fmt.Errorf("words number %d, expected %d: %w", wordsNum, expected, ErrUnexpectedWordsNum)
 	}
 
-	return nil
+	return mnemonic.Validate()
+}
+
+// Validate a mnemonic in strict mode, using the words list of the specified language.
+// In addition to Validate, the mnemonic shall be in canonical form: words separated by single spaces (without leading or trailing ones)
+// and each word exactly as in the words list (i.e. case-sensitive and NFKD-normalized), otherwise ErrNonCanonicalForm is returned.
+// For mnemonics created by MnemonicFromString or MnemonicFromStringLang, the original string is checked (i.e. before lowercasing and
+// collapsing whitespaces), unless Words was modified afterwards.
+// It can be used for enforcing canonical backups, since some wallets reject non-canonical mnemonics.
+func (mnemonic *Mnemonic) ValidateStrict(lang Language) error {
+	// Get words list
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return err
+	}
+
+	// Get the original string, if the words are still the normalized version of it
+	mnemonicStr := mnemonic.Words
+	if mnemonic.raw != "" && NormalizeMnemonic(mnemonic.raw) == mnemonic.Words {
+		mnemonicStr = mnemonic.raw
+	}
+
+	// Check separators
+	words := strings.Fields(mnemonicStr)
+	if strings.Join(words, wordsSeparator) != mnemonicStr {
+		return fmt.Errorf("words separators: %w", ErrNonCanonicalForm)
+	}
+	// Check words form, distinguishing words that are valid only after normalization
+	for i, word := range words {
+		if wordsList.exactWordIndex(word) == -1 && wordsList.wordIndex(strings.ToLower(word)) != -1 {
+			return fmt.Errorf("word %q at position %d: %w", word, i, ErrNonCanonicalForm)
+		}
+	}
+
+	// Validate words and checksum
+	entropy, err := MnemonicFromStringRaw(mnemonicStr).toEntropy(wordsList.exactWordIndex)
+	Wipe(entropy)
+	return err
+}
+
+// Get if a mnemonic is of low quality, i.e. it's likely a test mnemonic or it was generated from trivial entropy.
+// It's a heuristic and advisory check (e.g. for warning users who pasted an obvious test mnemonic into a real wallet):
+// a mnemonic is considered of low quality if its entropy bytes are all equal (e.g. all zeros or all ones, like "abandon ... about"),
+// or if its distinct words are at most one third of the words (i.e. it's mostly made of repeated words).
+// False is returned if the mnemonic is not valid, use Validate for checking it.
+func (mnemonic *Mnemonic) IsLowQuality() bool {
+	// Get entropy
+	entropy, err := mnemonic.ToEntropy()
+	if err != nil {
+		return false
+	}
+	defer Wipe(entropy)
+	if isWeakEntropy(entropy) {
+		return true
+	}
 
+	// Count distinct words
+	words := mnemonic.WordSlice()
+	distinctWords := make(map[string]bool, len(words))
+	for _, word := range words {
+		distinctWords[word] = true
+	}
+	return len(distinctWords) <= len(words) / 3
 }
 
 // Get if a mnemonic is valid.
@@ -205,74 +945,483 @@ func (mnemonic *Mnemonic) IsValid() bool {
 	return mnemonic.Validate() == nil
 }
 
+// Get the words of a mnemonic as a slice, split by whitespaces.
+// All Unicode whitespaces are separators (e.g. space, tab, newline and the Japanese ideographic space U+3000), independently from the language.
+// Empty words (e.g. due to leading, trailing or multiple whitespaces) are removed, so an empty or whitespace-only mnemonic has no words.
+// A new slice is returned at each call, so it can be modified by the caller.
+func (mnemonic *Mnemonic) WordSlice() []string {
+	return strings.Fields(mnemonic.Words)
+}
+
+// Get the words of a mnemonic numbered from 1, one entry per word (e.g. "1. abandon"), e.g. for printing a backup card.
+// The words are split as in WordSlice, so it works for any language.
+func (mnemonic *Mnemonic) NumberedWords() []string {
+	words := mnemonic.WordSlice()
+	for i, word := range words {
+		words[i] = fmt.Sprintf("%d. %s", i + 1, word)
+	}
+	return words
+}
+
+// Get the words of a mnemonic grouped into rows of the specified number of columns (e.g. 4 columns for a 6x4 grid), for display.
+// The last row is padded with empty strings if the words number is not a multiple of the columns, so all the rows have the same length.
+// The words are split as in WordSlice. Nil is returned if the columns are not positive.
+func (mnemonic *Mnemonic) Grid(columns int) [][]string {
+	if columns <= 0 {
+		return nil
+	}
+
+	words := mnemonic.WordSlice()
+	rowsNum := (len(words) + columns - 1) / columns
+	grid := make([][]string, rowsNum)
+	for i := range grid {
+		grid[i] = make([]string, columns)
+		copy(grid[i], words[i * columns:])
+	}
+	return grid
+}
+
+// Get the number of words of a mnemonic.
+func (mnemonic *Mnemonic) WordCount() int {
+	return len(mnemonic.WordSlice())
+}
+
+// Get the entropy bit length of a mnemonic, computed from its words number.
+// Error is returned if the words number is not valid.
+func (mnemonic *Mnemonic) EntropyBitLen() (int, error) {
+	return EntropyBitLenFromWordsNum(mnemonic.WordCount())
+}
+
+// Get if a mnemonic is equal to another one.
+// Whitespaces are normalized before comparing and the comparison is performed in constant time,
+// so it can be used for verifying a mnemonic re-entered by the user without leaking timing information.
+func (mnemonic *Mnemonic) Equal(other *Mnemonic) bool {
+	return subtle.ConstantTimeCompare([]byte(collapseWhitespaces(mnemonic.Words)),
+	                                  []byte(collapseWhitespaces(other.Words))) == 1
+}
+
+// Get the mnemonic words for display, keeping the specified number of first and last words and redacting the other ones.
+// Each redacted word is replaced by a placeholder (i.e. "****"), negative numbers are considered as zero.
+// If the words to keep are at least the mnemonic words, the whole mnemonic is returned.
+func (mnemonic *Mnemonic) Redact(showFirst int, showLast int) string {
+	words := mnemonic.WordSlice()
+	if showFirst < 0 {
+		showFirst = 0
+	}
+	if showLast < 0 {
+		showLast = 0
+	}
+
+	// Replace the middle words
+	if showFirst + showLast < len(words) {
+		for i := showFirst; i < len(words) - showLast; i++ {
+			words[i] = redactedWord
+		}
+	}
+
+	return strings.Join(words, wordsSeparator)
+}
+
+// Get if a mnemonic is equal to another one, like Equal but ignoring also the Unicode normal form.
+// Both mnemonics are NFKD-normalized and whitespaces are normalized before comparing in constant time,
+// so that cosmetic differences (e.g. composed or decomposed accented letters) don't matter.
+func (mnemonic *Mnemonic) EqualNormalized(other *Mnemonic) bool {
+	return subtle.ConstantTimeCompare([]byte(collapseWhitespaces(normalizeNfkd(mnemonic.Words))),
+	                                  []byte(collapseWhitespaces(normalizeNfkd(other.Words)))) == 1
+}
+
+// Get a copy of the mnemonic, independent from the original one.
+func (mnemonic *Mnemonic) Clone() *Mnemonic {
+	clone := *mnemonic
+	return &clone
+}
+
+// Get the language of a mnemonic, i.e. the one whose words list is used by its methods (e.g. Validate, ToEntropy).
+// It's English for mnemonics created by MnemonicFromStringRaw or without a constructor.
+func (mnemonic *Mnemonic) Language() Language {
+	return mnemonic.lang
+}
+
+// Get, for each language, the indexes of the mnemonic words belonging to its words list, e.g. for reporting a mixed-language mnemonic.
+// Words belonging to more than one language (e.g. "abandon" is both English and French) are reported under each of them,
+// words belonging to no supported language are reported under LangUnknown. The indexes are in ascending order.
+// Words are normalized like DetectLanguage, so the indexes are the ones of WordSlice. The mnemonic is not validated.
+func (mnemonic *Mnemonic) LanguageBreakdown() map[Language][]int {
+	langs := SupportedLanguages()
+	breakdown := make(map[Language][]int)
+	for i, word := range strings.Fields(NormalizeMnemonic(mnemonic.Words)) {
+		found := false
+		for _, lang := range langs {
+			if languageWordsListMap[lang].wordIndex(word) != -1 {
+				breakdown[lang] = append(breakdown[lang], i)
+				found = true
+			}
+		}
+		if !found {
+			breakdown[LangUnknown] = append(breakdown[LangUnknown], i)
+		}
+	}
+	return breakdown
+}
+
+// Get the mnemonic words as a string, implementing the fmt.Stringer interface.
+// Since the mnemonic is a secret, be careful when printing or logging it (e.g. with fmt or log packages).
+func (mnemonic *Mnemonic) String() string {
+	return mnemonic.Words
+}
+
+// Encode the mnemonic as a JSON string of its words, implementing the json.Marshaler interface.
+// The language is not encoded, since it's detected again when decoding.
+func (mnemonic *Mnemonic) MarshalJSON() ([]byte, error) {
+	return json.Marshal(mnemonic.Words)
+}
+
+// Decode the mnemonic from a JSON string of its words, implementing the json.Unmarshaler interface.
+// The mnemonic is built by MnemonicFromString, so the words are normalized and the language is detected (English if it cannot be
+// detected, e.g. for mnemonics valid in both Chinese languages). It's not validated.
+func (mnemonic *Mnemonic) UnmarshalJSON(data []byte) error {
+	var words string
+	err := json.Unmarshal(data, &words)
+	if err != nil {
+		return err
+	}
+
+	*mnemonic = *MnemonicFromString(words)
+	return nil
+}
+
 // Generate the seed from a mnemonic using the specified passphrase for protection.
+// Both mnemonic and passphrase are NFKD-normalized before generating the seed.
+// The salt is "mnemonic" followed by the passphrase, so an empty passphrase is valid and gives the salt "mnemonic".
 func (mnemonic *Mnemonic) GenerateSeed(passphrase string) ([]byte, error) {
+	return mnemonic.GenerateSeedKDF(passphrase, BIP39PBKDF2 {})
+}
+
+// Generate the seed from a mnemonic using the specified passphrase, salt prefix and number of PBKDF2 rounds.
+// It can be used for chains that derive the seed like BIP-0039 but with different parameters.
+// WARNING: BIP-0039 specifies "mnemonic" as salt prefix and 2048 rounds, any other value generates a seed that is NOT BIP-0039 compatible.
+func (mnemonic *Mnemonic) GenerateSeedWithSalt(passphrase string, saltPrefix string, rounds int) ([]byte, error) {
+	// Validate rounds
+	if rounds <= 0 {
+		return nil, fmt.Errorf("rounds %d: %w", rounds, ErrSeedRounds)
+	}
 	// Validate mnemonic
 	err := mnemonic.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	// Get salt
-	salt := seedSaltMod + passphrase
+	// Get words joined by single separators, so that the seed doesn't depend on whitespaces
+	return pbkdf2Seed(strings.Join(mnemonic.WordSlice(), wordsSeparator), passphrase, saltPrefix, rounds), nil
+}
+
+// Generate the seed from a mnemonic using the specified passphrase for protection, returning a reader over it.
+// It can be used for streaming the seed to other functions (e.g. with io.ReadFull).
+func (mnemonic *Mnemonic) GenerateSeedReader(passphrase string) (io.Reader, error) {
+	seed, err := mnemonic.GenerateSeed(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(seed), nil
+}
+
+// Generate the seed from a mnemonic using the specified passphrase for protection, returning it as hex string.
+func (mnemonic *Mnemonic) GenerateSeedHex(passphrase string) (string, error) {
+	seed, err := mnemonic.GenerateSeed(passphrase)
+	if err != nil {
+		return "", err
+	}
+	defer Wipe(seed)
+
+	return hex.EncodeToString(seed), nil
+}
+
+// Generate the seed from a mnemonic using the specified passphrase for protection, returning it as base64 string (standard encoding).
+func (mnemonic *Mnemonic) GenerateSeedBase64(passphrase string) (string, error) {
+	seed, err := mnemonic.GenerateSeed(passphrase)
+	if err != nil {
+		return "", err
+	}
+	defer Wipe(seed)
+
+	return base64.StdEncoding.EncodeToString(seed), nil
+}
+
+// Get the fingerprint of a mnemonic, i.e. the first 4 bytes of RIPEMD160(SHA256(seed)), e.g. for distinguishing wallets in a user interface.
+// The fingerprint is derived from the seed, so it changes if the passphrase changes. It cannot be reversed to get back the seed or the mnemonic.
+// Error is returned if the mnemonic is not valid.
+func (mnemonic *Mnemonic) Fingerprint(passphrase string) ([]byte, error) {
 	// Generate seed
-	return pbkdf2.Key([]byte(mnemonic.Words), []byte(salt), seedPbkdf2Round, seedPbkdf2KeyLen, sha512.New), nil
+	seed, err := mnemonic.GenerateSeed(passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer Wipe(seed)
+
+	// Compute hash
+	seedSha256 := sha256.Sum256(seed)
+	defer Wipe(seedSha256[:])
+	h := ripemd160.New()
+	h.Write(seedSha256[:])
+
+	return h.Sum(nil)[:fingerprintLen], nil
+}
+
+// Verify that the seed generated from a mnemonic using the specified passphrase is equal to the expected one.
+// The seeds are compared in constant time, so that no information is leaked through timing.
+// The mnemonic validation error is returned if the mnemonic is not valid.
+func (mnemonic *Mnemonic) VerifySeed(passphrase string, expectedSeed []byte) (bool, error) {
+	seed, err := mnemonic.GenerateSeed(passphrase)
+	if err != nil {
+		return false, err
+	}
+	defer Wipe(seed)
+
+	return subtle.ConstantTimeCompare(seed, expectedSeed) == 1, nil
+}
+
+// Get if the seeds generated from a mnemonic using the two specified passphrases differ.
+// Different passphrases shall always give different seeds, since the passphrase is part of the salt, so it can be used for checking it.
+// The mnemonic validation error is returned if the mnemonic is not valid.
+func (mnemonic *Mnemonic) SeedsDiffer(passA string, passB string) (bool, error) {
+	seedA, err := mnemonic.GenerateSeed(passA)
+	if err != nil {
+		return false, err
+	}
+	defer Wipe(seedA)
+	seedB, err := mnemonic.GenerateSeed(passB)
+	if err != nil {
+		return false, err
+	}
+	defer Wipe(seedB)
+
+	return subtle.ConstantTimeCompare(seedA, seedB) == 0, nil
+}
+
+// Verify that a mnemonic encodes exactly the specified entropy, e.g. for checking a written backup against the generated entropy.
+// The entropy got back from the mnemonic (see ToEntropy) is compared in constant time, so that no information is leaked through timing.
+// The ToEntropy error is returned if the mnemonic is not valid.
+func (mnemonic *Mnemonic) MatchesEntropy(entropy []byte) (bool, error) {
+	mnemonicEntropy, err := mnemonic.ToEntropy()
+	if err != nil {
+		return false, err
+	}
+	defer Wipe(mnemonicEntropy)
+
+	return subtle.ConstantTimeCompare(mnemonicEntropy, entropy) == 1, nil
 }
 
 //
 // Not-exported functions
 //
 
+// Convert the specified entropy to mnemonic words using the specified words list.
+// The entropy slice shall be already validated.
+func entropyToWords(entropy []byte, wordsList []string) string {
+	// Get word indexes
+	wordsIdx := entropyToWordIndexes(entropy)
+
+	// Map each index to the words list
+	mnemonic := make([]string, 0, len(wordsIdx))
+	for _, wordIdx := range wordsIdx {
+		mnemonic = append(mnemonic, wordsList[wordIdx])
+	}
+
+	return strings.Join(mnemonic, wordsSeparator)
+}
+
+// Count the words of the specified string, separated by whitespaces, without allocating memory.
+func countWords(str string) int {
+	wordsNum := 0
+	for word, rest := nextWord(str); word != ""; word, rest = nextWord(rest) {
+		wordsNum++
+	}
+	return wordsNum
+}
+
+// Get the first word of the specified string, separated by whitespaces, and the remaining string.
+// An empty word is returned if there are no more words.
+func nextWord(str string) (string, string) {
+	// Skip leading whitespaces
+	start := 0
+	for start < len(str) {
+		size := spaceLen(str[start:])
+		if size == 0 {
+			break
+		}
+		start += size
+	}
+	// Find the end of the word
+	end := start
+	for end < len(str) && spaceLen(str[end:]) == 0 {
+		_, size := utf8.DecodeRuneInString(str[end:])
+		end += size
+	}
+	return str[start:end], str[end:]
+}
+
+// Get the length in bytes of the whitespace at the beginning of the specified string, zero if it doesn't begin with a whitespace.
+// ASCII characters are checked with a table, since they are the most common ones.
+func spaceLen(str string) int {
+	if str[0] < utf8.RuneSelf {
+		return int(asciiSpace[str[0]])
+	}
+	r, size := utf8.DecodeRuneInString(str)
+	if unicode.IsSpace(r) {
+		return size
+	}
+	return 0
+}
+
+// Get if the specified entropy is weak, i.e. all its bytes are equal.
+func isWeakEntropy(entropy []byte) bool {
+	for _, b := range entropy[1:] {
+		if b != entropy[0] {
+			return false
+		}
+	}
+	return true
+}
+
 // Validate the specified words number.
 func validateWordsNum(wordsNum int) error {
 	if !wordsNumMap[wordsNum] {
-		return ErrWordsNum
+		return fmt.Errorf("words number %d: %w", wordsNum, ErrWordsNum)
 	}
 	return nil
 }
 
-// Compute checksum of the specified entropy bytes, returned as a binary string.
-func entropyChecksumBinStr(slice []byte) string {
+// Validate the specified words list length.
+func validateWordsListLen(wordsList []string) error {
+	if len(wordsList) != wordsListLen {
+		return fmt.Errorf("words list length %d: %w", len(wordsList), ErrInvalidWordlistLen)
+	}
+	return nil
+}
+
+// Validate the specified custom words list, i.e. its length and the absence of duplicate words, and get the map from each word to its index.
+func customWordsIdxMap(wordsList []string) (map[string]int, error) {
+	// Validate words list length
+	err := validateWordsListLen(wordsList)
+	if err != nil {
+		return nil, err
+	}
+
+	// Build map, checking for duplicates
+	wordsIdxMap := make(map[string]int, len(wordsList))
+	for i, word := range wordsList {
+		if prevIdx, ok := wordsIdxMap[word]; ok {
+			return nil, fmt.Errorf("word %q at indexes %d and %d: %w", word, prevIdx, i, ErrDuplicateWord)
+		}
+		wordsIdxMap[word] = i
+	}
+	return wordsIdxMap, nil
+}
+
+// Get the entropy bit length from the specified words number.
+func wordsNumToEntropyBitLen(wordsNum int) int {
+	return (wordsNum * WordBitLen) - (wordsNum / 3)
+}
+
+// Convert the specified entropy bytes to word indexes, including the checksum.
+// The entropy bit length shall be already validated.
+func entropyToWordIndexes(entropy []byte) []int {
+	// Compute checksum and append it to entropy, aligned to the most significant bits
+	chksumBitLen := entropyChecksumBitLen(entropy)
+	mnemonicBytes := make([]byte, len(entropy), len(entropy) + 1)
+	copy(mnemonicBytes, entropy)
+	mnemonicBytes = append(mnemonicBytes, entropyChecksum(entropy) << (8 - chksumBitLen))
+	defer Wipe(mnemonicBytes)
+
+	// Split the bits in groups of 11-bit
+	return bytesToWordIndexes(mnemonicBytes, (len(entropy) * 8) + chksumBitLen)
+}
+
+// Get the words number from the specified entropy bit length.
+func entropyBitLenToWordsNum(bitLen int) int {
+	return (bitLen + ChecksumBitLen(bitLen)) / WordBitLen
+}
+
+// Get the checksum bit length of the specified entropy bytes.
+func entropyChecksumBitLen(slice []byte) int {
+	return ChecksumBitLen(len(slice) * 8)
+}
+
+// Compute checksum of the specified entropy bytes.
+// The checksum bits are returned in the least significant bits of the byte.
+func entropyChecksum(slice []byte) byte {
 	// Compute SHA256
 	hash := sha256.Sum256(slice)
-	// Convert to binary string
-	hashStr := bytesToBinaryString(hash[:])
-	// Get checksum length in bits
-	chksumBitLen := len(slice) / 4
+	// Take the first bits of the hash
+	return hash[0] >> (8 - entropyChecksumBitLen(slice))
+}
 
-	return hashStr[:chksumBitLen]
+// Convert a mnemonic back to entropy bytes, using the specified function for getting word indexes.
+// Error is returned if mnemonic or checksum is not valid.
+func (mnemonic *Mnemonic) toEntropy(wordIndex func(string) int) ([]byte, error) {
+	// Get entropy and checksum from mnemonic
+	entropy, chksum, err := mnemonic.getEntropyAndChecksum(wordIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	// Compare checksum
+	if expChksum := entropyChecksum(entropy); expChksum != chksum {
+		Wipe(entropy)
+		return nil, newChecksumError(chksum, expChksum, entropyChecksumBitLen(entropy))
+	}
+
+	return entropy, nil
 }
 
-// Get the binary strings back from a mnemonic.
-// The function returns both entropy and checksum parts.
-func (mnemonic *Mnemonic) getBinaryStrings() (string, string, error) {
+// Get the entropy bytes and checksum back from a mnemonic, using the specified function for getting word indexes.
+// The checksum bits are returned in the least significant bits of the byte.
+func (mnemonic *Mnemonic) getEntropyAndChecksum(wordIndex func(string) int) ([]byte, byte, error) {
+	// Get the index of each word
+	wordsIdx, err := mnemonic.getWordIndexes(wordIndex)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entropy, chksum := wordIndexesToEntropyAndChecksum(wordsIdx)
+	return entropy, chksum, nil
+}
+
+// Get the index of each word of a mnemonic, using the specified function for getting word indexes.
+func (mnemonic *Mnemonic) getWordIndexes(wordIndex func(string) int) ([]int, error) {
 	// Get word list
-	wordsList := strings.Split(mnemonic.Words, " ")
+	wordsList := mnemonic.WordSlice()
 	// Validate words number
 	err := validateWordsNum(len(wordsList))
 	if err != nil {
-		return "", "", err
+		return nil, err
 	}
 
-	// Build the binary string by converting each word index
-	var strBuf bytes.Buffer
-	for _, word := range wordsList {
-		// Use binary search for getting the word index
-		wordIdx := stringBinarySearch(wordsListEn, word)
+	// Get the index of each word
+	wordsIdx := make([]int, 0, len(wordsList))
+	for i, word := range wordsList {
+		// Get the word index
+		wordIdx := wordIndex(word)
 		// Error if not found
 		if wordIdx == -1 {
-			return "", "", ErrInvalidWord
+			return nil, &WordError { Index: i, Word: word }
 		}
-		// Convert the index to 11-bit binary string
-		strBuf.WriteString(fmt.Sprintf("%.11b", wordIdx))
+		wordsIdx = append(wordsIdx, wordIdx)
 	}
 
-	// Get mnemonic binary string
-	mnemonicBinStr := strBuf.String()
-	// Compute checksum length and index
-	chksumLen := len(mnemonicBinStr) / 33
-	chksumIdx := len(mnemonicBinStr) - chksumLen
+	return wordsIdx, nil
+}
+
+// Get the entropy bytes and checksum from the specified word indexes.
+// The words number shall be already validated.
+// The checksum bits are returned in the least significant bits of the byte.
+func wordIndexesToEntropyAndChecksum(wordsIdx []int) ([]byte, byte) {
+	// Pack the 11-bit indexes into bytes
+	mnemonicBytes := wordIndexesToBytes(wordsIdx)
+	// Compute entropy and checksum length
+	chksumBitLen := len(wordsIdx) / 3
+	entropyLen := ((len(wordsIdx) * WordBitLen) - chksumBitLen) / 8
 
 	// Split mnemonic
-	return mnemonicBinStr[:chksumIdx], mnemonicBinStr[chksumIdx:], nil
+	return mnemonicBytes[:entropyLen], mnemonicBytes[entropyLen] >> (8 - chksumBitLen)
 }
@@ -0,0 +1,244 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+//
+// This file contains the Monero mnemonic encoding for bip39 package.
+// Monero mnemonics are not BIP-0039 mnemonics: they encode a 32-byte seed in 24 words of a 1626-word list,
+// plus a checksum word computed with CRC32.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"strings"
+	"golang.org/x/text/unicode/norm"
+)
+
+//
+// Types
+//
+
+// Structure for a Monero words list, i.e. the 1626 words and the number of characters of each word used for computing the checksum
+// (e.g. 3 for the Monero English words list). The words are normalized like the mnemonic words (see MoneroMnemonicToSeed).
+type MoneroWordsList struct {
+	// Words
+	words []string
+	// Number of characters of each word used for computing the checksum
+	prefixLen int
+	// Map from word to index
+	wordsIdxMap map[string]int
+}
+
+//
+// Constants
+//
+const (
+	// Monero words list length
+	moneroWordsListLen = 1626
+	// Monero seed length in bytes
+	moneroSeedLen = 32
+	// Monero words number (including checksum word)
+	moneroWordsNum = 25
+)
+
+//
+// Variables
+//
+var (
+	// ErrMoneroSeedLen is returned when trying to encode a Monero seed with invalid length
+	ErrMoneroSeedLen = errors.New("The Monero seed shall be 32 bytes long")
+	// ErrMoneroWordlistLen is returned when trying to use a Monero words list with invalid length
+	ErrMoneroWordlistLen = errors.New("The Monero words list shall contain exactly 1626 words")
+	// ErrMoneroPrefixLen is returned when trying to use a Monero words list with invalid checksum prefix length
+	ErrMoneroPrefixLen = errors.New("The Monero words list checksum prefix length shall be positive")
+	// ErrMoneroWordsListNotSet is returned when trying to encode or decode a Monero mnemonic without setting the words list
+	ErrMoneroWordsListNotSet = errors.New("The Monero words list is not set")
+
+	// Monero words list used for encoding and decoding, set by SetMoneroWordsList
+	moneroWordsList *MoneroWordsList
+)
+
+//
+// Exported functions
+//
+
+// Create a Monero words list from the specified words (exactly 1626 different words, e.g. the Monero English words list)
+// and the specified number of characters of each word used for computing the checksum (e.g. 3 for the Monero English words list).
+// The words are lowercased and NFC-normalized, as the mnemonic words. Error is returned if words or prefix length are not valid.
+func NewMoneroWordsList(words []string, prefixLen int) (*MoneroWordsList, error) {
+	// Validate words list length
+	if len(words) != moneroWordsListLen {
+		return nil, fmt.Errorf("words list length %d: %w", len(words), ErrMoneroWordlistLen)
+	}
+	// Validate prefix length
+	if prefixLen <= 0 {
+		return nil, fmt.Errorf("prefix length %d: %w", prefixLen, ErrMoneroPrefixLen)
+	}
+
+	// Normalize words and build map, checking for duplicates
+	normWords := make([]string, len(words))
+	wordsIdxMap := make(map[string]int, len(words))
+	for i, word := range words {
+		normWords[i] = moneroNormalizeWord(word)
+		if prevIdx, ok := wordsIdxMap[normWords[i]]; ok {
+			return nil, fmt.Errorf("word %q at indexes %d and %d: %w", normWords[i], prevIdx, i, ErrDuplicateWord)
+		}
+		wordsIdxMap[normWords[i]] = i
+	}
+
+	return &MoneroWordsList {
+		words:       normWords,
+		prefixLen:   prefixLen,
+		wordsIdxMap: wordsIdxMap,
+	}, nil
+}
+
+// Set the Monero words list used by MoneroMnemonicFromSeed and MoneroMnemonicToSeed, nil for unsetting it.
+// It's not safe for concurrent use, so it shall be called before encoding or decoding any Monero mnemonic.
+func SetMoneroWordsList(wordsList *MoneroWordsList) {
+	moneroWordsList = wordsList
+}
+
+// Generate a Monero mnemonic (25 words) from the specified 32-byte seed, using the words list set by SetMoneroWordsList.
+// WARNING: the returned mnemonic is not a BIP-0039 mnemonic, so its methods (e.g. Validate, GenerateSeed, ToEntropy) shall not be used.
+// It shall be converted back only with MoneroMnemonicToSeed.
+// Error is returned if the seed length is not valid or the words list is not set.
+func MoneroMnemonicFromSeed(seed []byte) (*Mnemonic, error) {
+	// Get words list
+	wordsList, err := getMoneroWordsList()
+	if err != nil {
+		return nil, err
+	}
+	// Validate seed length
+	if len(seed) != moneroSeedLen {
+		return nil, fmt.Errorf("seed length %d: %w", len(seed), ErrMoneroSeedLen)
+	}
+
+	// Encode each 4-byte group (little endian) to 3 words
+	n := uint32(moneroWordsListLen)
+	words := make([]string, 0, moneroWordsNum)
+	for i := 0; i < len(seed); i += 4 {
+		val := binary.LittleEndian.Uint32(seed[i: i + 4])
+
+		w1 := val % n
+		w2 := ((val / n) + w1) % n
+		w3 := (((val / n) / n) + w2) % n
+		words = append(words, wordsList.words[w1], wordsList.words[w2], wordsList.words[w3])
+	}
+	// Append checksum word
+	words = append(words, words[wordsList.checksumIndex(words)])
+
+	return &Mnemonic {
+		Words: strings.Join(words, wordsSeparator),
+	}, nil
+}
+
+// Convert the specified Monero mnemonic (25 words) back to the 32-byte seed, using the words list set by SetMoneroWordsList.
+// The words are normalized by NormalizeMnemonic and NFC-normalized, so leading, trailing and multiple whitespaces,
+// uppercase letters and decomposed accents are accepted.
+// Error is returned if the words list is not set, or if the mnemonic or its checksum is not valid.
+func MoneroMnemonicToSeed(mnemonic *Mnemonic) ([]byte, error) {
+	// Get words list
+	wordsList, err := getMoneroWordsList()
+	if err != nil {
+		return nil, err
+	}
+
+	// Validate words number
+	words := strings.Fields(NormalizeMnemonic(mnemonic.Words))
+	if len(words) != moneroWordsNum {
+		return nil, fmt.Errorf("words number %d: %w", len(words), ErrWordsNum)
+	}
+	for i := range words {
+		words[i] = moneroNormalizeWord(words[i])
+	}
+
+	// Decode each group of 3 words to 4 bytes (little endian)
+	n := uint64(moneroWordsListLen)
+	seed := make([]byte, moneroSeedLen)
+	for i := 0; i < moneroWordsNum - 1; i += 3 {
+		var wordsIdx [3]uint64
+		for j := range wordsIdx {
+			wordIdx, ok := wordsList.wordsIdxMap[words[i + j]]
+			if !ok {
+				Wipe(seed)
+				return nil, &WordError { Index: i + j, Word: words[i + j] }
+			}
+			wordsIdx[j] = uint64(wordIdx)
+		}
+
+		val := wordsIdx[0] +
+		       n * ((n - wordsIdx[0] + wordsIdx[1]) % n) +
+		       n * n * ((n - wordsIdx[1] + wordsIdx[2]) % n)
+		// Not all the words combinations encode a 32-bit value
+		if val > math.MaxUint32 {
+			Wipe(seed)
+			return nil, fmt.Errorf("invalid words group at position %d: %w", i, ErrInvalidWord)
+		}
+		binary.LittleEndian.PutUint32(seed[(i / 3) * 4:], uint32(val))
+	}
+
+	// Verify checksum word
+	if expChksumWord := words[wordsList.checksumIndex(words[:moneroWordsNum - 1])]; words[moneroWordsNum - 1] != expChksumWord {
+		Wipe(seed)
+		return nil, &ChecksumError { Got: words[moneroWordsNum - 1], Want: expChksumWord }
+	}
+
+	return seed, nil
+}
+
+//
+// Not-exported functions
+//
+
+// Get the Monero words list set by SetMoneroWordsList.
+func getMoneroWordsList() (*MoneroWordsList, error) {
+	if moneroWordsList == nil {
+		return nil, ErrMoneroWordsListNotSet
+	}
+	return moneroWordsList, nil
+}
+
+// Normalize the specified Monero word, i.e. lowercase and NFC-normalized.
+// NFC is used (instead of NFKD as for BIP-0039) because Monero computes the checksum on the characters of the composed words.
+func moneroNormalizeWord(word string) string {
+	return norm.NFC.String(strings.ToLower(word))
+}
+
+// Get the index of the checksum word, computed as the CRC32 of the words prefixes modulo the words number.
+func (wordsList *MoneroWordsList) checksumIndex(words []string) int {
+	var prefixes strings.Builder
+	for _, word := range words {
+		runes := []rune(word)
+		if len(runes) > wordsList.prefixLen {
+			runes = runes[:wordsList.prefixLen]
+		}
+		prefixes.WriteString(string(runes))
+	}
+
+	return int(crc32.ChecksumIEEE([]byte(prefixes.String())) % uint32(len(words)))
+}
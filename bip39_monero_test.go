@@ -0,0 +1,256 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+//
+// Types
+//
+
+// Monero test vector entry structure
+type testVectMoneroEntry struct {
+	PrefixLen int
+	Seed      string
+	Mnemonic  string
+}
+
+// Monero invalid mnemonic test vector entry structure
+type testVectMoneroInvalidEntry struct {
+	Mnemonic string
+	Err      error
+}
+
+//
+// Variables
+//
+
+// Tests for Monero mnemonic encoding (with the words list returned by testMoneroWordsList)
+var testVectMonero = []testVectMoneroEntry {
+	testVectMoneroEntry {
+		PrefixLen: 3,
+		Seed:      "0000000000000000000000000000000000000000000000000000000000000000",
+		Mnemonic:  "aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax",
+	},
+	testVectMoneroEntry {
+		PrefixLen: 3,
+		Seed:      "0102030401020304010203040102030401020304010203040102030401020304",
+		Mnemonic:  "blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx",
+	},
+	testVectMoneroEntry {
+		PrefixLen: 3,
+		Seed:      "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		Mnemonic:  "asvx bxwx bxux asvx bxwx bxux asvx bxwx bxux asvx bxwx bxux asvx bxwx bxux asvx bxwx bxux asvx bxwx bxux asvx bxwx bxux bxux",
+	},
+	testVectMoneroEntry {
+		PrefixLen: 3,
+		Seed:      "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		Mnemonic:  "acox ahzx aisx aqcx bzrx cbjx bdqx bgvx bjnx brex anzx arqx cesx cfrx ckix ahsx bmwx bsmx avgx auax bapx biux abex aitx ahzx",
+	},
+	// Checksum word depends on the prefix length of the words list
+	testVectMoneroEntry {
+		PrefixLen: 1,
+		Seed:      "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		Mnemonic:  "acox ahzx aisx aqcx bzrx cbjx bdqx bgvx bjnx brex anzx arqx cesx cfrx ckix ahsx bmwx bsmx avgx auax bapx biux abex aitx bjnx",
+	},
+	testVectMoneroEntry {
+		PrefixLen: 4,
+		Seed:      "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f",
+		Mnemonic:  "acox ahzx aisx aqcx bzrx cbjx bdqx bgvx bjnx brex anzx arqx cesx cfrx ckix ahsx bmwx bsmx avgx auax bapx biux abex aitx arqx",
+	},
+}
+
+// Tests for invalid Monero mnemonics
+var testVectMoneroInvalid = []testVectMoneroInvalidEntry {
+	// Invalid words number
+	testVectMoneroInvalidEntry {
+		Mnemonic: "aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax",
+		Err:      ErrWordsNum,
+	},
+	// Invalid word
+	testVectMoneroInvalidEntry {
+		Mnemonic: "aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax zzzx aaax",
+		Err:      ErrInvalidWord,
+	},
+	// Words group not encoding a 32-bit value
+	testVectMoneroInvalidEntry {
+		Mnemonic: "aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax aaax cknx aaax",
+		Err:      ErrInvalidWord,
+	},
+	// Invalid checksum
+	testVectMoneroInvalidEntry {
+		Mnemonic: "blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx adgx aefx blfx adgx aefx adgx",
+		Err:      ErrChecksum,
+	},
+}
+
+//
+// Functions
+//
+
+// Test Monero mnemonic encoding
+func TestMoneroVector(t *testing.T) {
+	defer SetMoneroWordsList(nil)
+
+	for _, currTest := range testVectMonero {
+		setTestMoneroWordsList(t, testMoneroWordsList(), currTest.PrefixLen)
+		seed, _ := hex.DecodeString(currTest.Seed)
+
+		// Test mnemonic from seed
+		mnemonic, err := MoneroMnemonicFromSeed(seed)
+		if err != nil {
+			t.Errorf("Monero mnemonic from seed %s returned error: %s", currTest.Seed, err.Error())
+			continue
+		}
+		if mnemonic.Words != currTest.Mnemonic {
+			t.Errorf("Monero mnemonic from seed was incorrect: expected %s, got: %s", currTest.Mnemonic, mnemonic.Words)
+		}
+
+		// Test seed from mnemonic
+		seedBack, err := MoneroMnemonicToSeed(mnemonic)
+		if err != nil {
+			t.Errorf("Monero mnemonic '%s' to seed returned error: %s", currTest.Mnemonic, err.Error())
+			continue
+		}
+		seedBackHex := hex.EncodeToString(seedBack)
+		if seedBackHex != currTest.Seed {
+			t.Errorf("Monero mnemonic to seed was incorrect: expected %s, got: %s", currTest.Seed, seedBackHex)
+		}
+
+		// Words shall be normalized
+		seedBack, err = MoneroMnemonicToSeed(&Mnemonic { Words: "  " + strings.ToUpper(mnemonic.Words) + "\n" })
+		if err != nil || hex.EncodeToString(seedBack) != currTest.Seed {
+			t.Errorf("Monero mnemonic '%s' (not normalized) to seed was incorrect: expected %s, got: %x (%v)", currTest.Mnemonic, currTest.Seed, seedBack, err)
+		}
+	}
+}
+
+// Test Monero words list normalization
+func TestMoneroWordsListNormalization(t *testing.T) {
+	defer SetMoneroWordsList(nil)
+
+	// Uppercase words list shall be equivalent
+	wordsList := testMoneroWordsList()
+	for i := range wordsList {
+		wordsList[i] = strings.ToUpper(wordsList[i])
+	}
+	setTestMoneroWordsList(t, wordsList, testVectMonero[3].PrefixLen)
+
+	seed, _ := hex.DecodeString(testVectMonero[3].Seed)
+	mnemonic, err := MoneroMnemonicFromSeed(seed)
+	if err != nil || mnemonic.Words != testVectMonero[3].Mnemonic {
+		t.Errorf("Monero mnemonic from uppercase words list was incorrect: expected %s, got: %v (%v)", testVectMonero[3].Mnemonic, mnemonic, err)
+	}
+
+	// Composed and decomposed accents shall be equivalent, both in the words list and in the mnemonic
+	wordsList = testMoneroWordsList()
+	wordsList[0] = "a\u0301aax"
+	setTestMoneroWordsList(t, wordsList, 3)
+
+	mnemonic, err = MoneroMnemonicFromSeed(make([]byte, moneroSeedLen))
+	if err != nil || mnemonic.Words != strings.TrimSpace(strings.Repeat("\u00e1aax ", moneroWordsNum)) {
+		t.Errorf("Monero mnemonic from accented words list was incorrect: got: %v (%v)", mnemonic, err)
+	}
+	seedBack, err := MoneroMnemonicToSeed(&Mnemonic { Words: strings.Repeat("A\u0301AAX ", moneroWordsNum) })
+	if err != nil || !bytes.Equal(seedBack, make([]byte, moneroSeedLen)) {
+		t.Errorf("Monero mnemonic with decomposed accents to seed was incorrect: got: %x (%v)", seedBack, err)
+	}
+}
+
+// Test invalid Monero mnemonics
+func TestMoneroInvalid(t *testing.T) {
+	defer SetMoneroWordsList(nil)
+	setTestMoneroWordsList(t, testMoneroWordsList(), 3)
+
+	for _, currTest := range testVectMoneroInvalid {
+		seed, err := MoneroMnemonicToSeed(&Mnemonic { Words: currTest.Mnemonic })
+		if seed != nil {
+			t.Errorf("Seed from invalid Monero mnemonic (%s) was not nil", currTest.Mnemonic)
+		}
+		if !errors.Is(err, currTest.Err) {
+			t.Errorf("Seed from invalid Monero mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+
+	// Invalid seed length
+	if _, err := MoneroMnemonicFromSeed(make([]byte, 16)); !errors.Is(err, ErrMoneroSeedLen) {
+		t.Errorf("Monero mnemonic from invalid seed length returned wrong error (%v)", err)
+	}
+
+	// Invalid words lists
+	wordsList := testMoneroWordsList()
+	if _, err := NewMoneroWordsList(wordsList[1:], 3); !errors.Is(err, ErrMoneroWordlistLen) {
+		t.Errorf("Monero words list with invalid length returned wrong error (%v)", err)
+	}
+	if _, err := NewMoneroWordsList(wordsListEn, 3); !errors.Is(err, ErrMoneroWordlistLen) {
+		t.Errorf("Monero words list with invalid length returned wrong error (%v)", err)
+	}
+	if _, err := NewMoneroWordsList(wordsList, 0); !errors.Is(err, ErrMoneroPrefixLen) {
+		t.Errorf("Monero words list with invalid prefix length returned wrong error (%v)", err)
+	}
+	wordsList[1] = strings.ToUpper(wordsList[0])
+	if _, err := NewMoneroWordsList(wordsList, 3); !errors.Is(err, ErrDuplicateWord) {
+		t.Errorf("Monero words list with duplicate words returned wrong error (%v)", err)
+	}
+
+	// Words list not set
+	SetMoneroWordsList(nil)
+	if _, err := MoneroMnemonicFromSeed(make([]byte, moneroSeedLen)); !errors.Is(err, ErrMoneroWordsListNotSet) {
+		t.Errorf("Monero mnemonic without words list returned wrong error (%v)", err)
+	}
+	if _, err := MoneroMnemonicToSeed(&Mnemonic { Words: testVectMonero[0].Mnemonic }); !errors.Is(err, ErrMoneroWordsListNotSet) {
+		t.Errorf("Monero seed without words list returned wrong error (%v)", err)
+	}
+}
+
+// Get a test Monero words list, whose words have unique 3-character prefixes (i.e. aaax, aabx, ...).
+func testMoneroWordsList() []string {
+	const letters = "abcdefghijklmnopqrstuvwxyz"
+
+	wordlist := make([]string, 0, moneroWordsListLen)
+	for i := 0; len(wordlist) < moneroWordsListLen; i++ {
+		var word strings.Builder
+		word.WriteByte(letters[(i / (26 * 26)) % 26])
+		word.WriteByte(letters[(i / 26) % 26])
+		word.WriteByte(letters[i % 26])
+		word.WriteByte('x')
+		wordlist = append(wordlist, word.String())
+	}
+	return wordlist
+}
+
+// Set the specified test Monero words list with the specified prefix length.
+func setTestMoneroWordsList(t *testing.T, words []string, prefixLen int) {
+	wordsList, err := NewMoneroWordsList(words, prefixLen)
+	if err != nil {
+		t.Fatalf("Monero words list creation returned error: %s", err.Error())
+	}
+	SetMoneroWordsList(wordsList)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains the passphrase validation for bip39 package.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"errors"
+	"fmt"
+	"unicode"
+)
+
+//
+// Variables
+//
+var (
+	// ErrPassphraseControlChars is returned when a passphrase contains control characters
+	ErrPassphraseControlChars = errors.New("The passphrase contains control characters")
+)
+
+//
+// Exported functions
+//
+
+// Validate the specified passphrase.
+// A passphrase is considered not valid if it contains control characters (i.e. Unicode category Cc), including tabs and newlines.
+// This is stricter than BIP-0039, which accepts any string, but it catches common mistakes like a trailing newline when reading the passphrase from a file,
+// which would silently result in a different seed.
+func ValidatePassphrase(passphrase string) error {
+	for i, r := range passphrase {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("character %U at byte offset %d: %w", r, i, ErrPassphraseControlChars)
+		}
+	}
+	return nil
+}
+
+// Generate the seed from a mnemonic using the specified passphrase for protection, like GenerateSeed.
+// Differently from GenerateSeed, the passphrase is validated by ValidatePassphrase before generating the seed.
+func (mnemonic *Mnemonic) GenerateSeedStrict(passphrase string) ([]byte, error) {
+	// Validate passphrase
+	err := ValidatePassphrase(passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return mnemonic.GenerateSeed(passphrase)
+}
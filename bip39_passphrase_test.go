@@ -0,0 +1,98 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+//
+// Variables
+//
+
+// Tests for valid passphrases
+var testVectPassphraseValid = []string {
+	"",
+	"TREZOR",
+	"my passphrase",
+	"パスフレーズ",
+}
+
+// Tests for invalid passphrases
+var testVectPassphraseInvalid = []string {
+	"TREZOR\n",
+	"TREZOR\r\n",
+	"\tTREZOR",
+	"TRE\x00ZOR",
+	"TREZOR\x7f",
+	"TREZOR\u0085",
+}
+
+//
+// Functions
+//
+
+// Test valid passphrases
+func TestPassphraseValid(t *testing.T) {
+	mnemonic := MnemonicFromString(testVect[0].Mnemonic)
+
+	for _, testStr := range testVectPassphraseValid {
+		err := ValidatePassphrase(testStr)
+		if err != nil {
+			t.Errorf("Passphrase %q validation returned error: %s", testStr, err.Error())
+		}
+
+		// Strict seed shall be the same of the normal one
+		seed, _ := mnemonic.GenerateSeed(testStr)
+		seedStrict, err := mnemonic.GenerateSeedStrict(testStr)
+		if err != nil {
+			t.Errorf("Passphrase %q strict seed generation returned error: %s", testStr, err.Error())
+		} else if !bytes.Equal(seed, seedStrict) {
+			t.Errorf("Passphrase %q strict seed was incorrect: expected %x, got: %x", testStr, seed, seedStrict)
+		}
+	}
+}
+
+// Test invalid passphrases
+func TestPassphraseInvalid(t *testing.T) {
+	mnemonic := MnemonicFromString(testVect[0].Mnemonic)
+
+	for _, testStr := range testVectPassphraseInvalid {
+		err := ValidatePassphrase(testStr)
+		if !errors.Is(err, ErrPassphraseControlChars) {
+			t.Errorf("Passphrase %q validation returned wrong error (%v)", testStr, err)
+		}
+
+		// Seed shall be nil and error shall be not nil
+		seed, err := mnemonic.GenerateSeedStrict(testStr)
+		if seed != nil {
+			t.Errorf("Passphrase %q strict seed was not nil", testStr)
+		}
+		if !errors.Is(err, ErrPassphraseControlChars) {
+			t.Errorf("Passphrase %q strict seed generation returned wrong error (%v)", testStr, err)
+		}
+	}
+}
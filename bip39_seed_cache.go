@@ -0,0 +1,160 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains the seed cache for bip39 package.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"sync"
+)
+
+//
+// Constants
+//
+const (
+	// Length in bytes of the key for hashing passphrases
+	seedCacheKeyLen = 32
+)
+
+//
+// Types
+//
+
+// Structure for a cache of the seeds generated from a mnemonic, one for each passphrase.
+// It trades memory for CPU: the 2048 PBKDF2 rounds are computed only once for each passphrase (e.g. when the seed is needed
+// at every screen refresh), at the cost of keeping the seeds in memory until Clear is called.
+// WARNING: holding seeds in memory longer increases their exposure, so use it only if needed and call Clear as soon as possible.
+// The passphrases are never stored, the cache is keyed by their HMAC-SHA256 with a random key of the cache
+// (so the keys cannot be used for checking guessed passphrases offline). It's safe for concurrent use.
+type SeedCache struct {
+	// Cached mnemonic
+	mnemonic *Mnemonic
+	// Key for hashing passphrases, nil after clear
+	key []byte
+	// Seeds, keyed by passphrase hash
+	seeds map[[sha256.Size]byte][]byte
+	// Guard for seeds
+	mutex sync.Mutex
+}
+
+//
+// Exported functions
+//
+
+// Get an empty seed cache for a mnemonic.
+// The mnemonic is cloned, so later modifications of it don't affect the cached seeds.
+// Error is returned if the random key of the cache cannot be generated.
+func (mnemonic *Mnemonic) SeedCache() (*SeedCache, error) {
+	key, err := newSeedCacheKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeedCache {
+		mnemonic: mnemonic.Clone(),
+		key:      key,
+		seeds:    make(map[[sha256.Size]byte][]byte),
+	}, nil
+}
+
+// Generate the seed from the cached mnemonic using the specified passphrase, like Mnemonic.GenerateSeed.
+// The seed is computed only the first time for each passphrase (the NFKD-normalized one), then it's got from the cache.
+// A copy of the seed is returned, so it can be wiped by the caller. Errors are not cached.
+func (cache *SeedCache) GenerateSeed(passphrase string) ([]byte, error) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	// Generate a new hashing key if cleared
+	if cache.key == nil {
+		var err error
+		cache.key, err = newSeedCacheKey()
+		if err != nil {
+			return nil, err
+		}
+	}
+	key := cache.passphraseHash(passphrase)
+
+	// Generate seed if not cached
+	seed, ok := cache.seeds[key]
+	if !ok {
+		var err error
+		seed, err = cache.mnemonic.GenerateSeed(passphrase)
+		if err != nil {
+			return nil, err
+		}
+		cache.seeds[key] = seed
+	}
+
+	return append([]byte {}, seed...), nil
+}
+
+// Get the number of cached seeds.
+func (cache *SeedCache) Len() int {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	return len(cache.seeds)
+}
+
+// Wipe all the cached seeds and the hashing key, and empty the cache.
+// The cache can be still used afterwards, the seeds will be computed again (with a new hashing key).
+func (cache *SeedCache) Clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	for key, seed := range cache.seeds {
+		Wipe(seed)
+		delete(cache.seeds, key)
+	}
+	Wipe(cache.key)
+	cache.key = nil
+}
+
+//
+// Not-exported functions
+//
+
+// Generate a random key for hashing passphrases.
+func newSeedCacheKey() ([]byte, error) {
+	key := make([]byte, seedCacheKeyLen)
+	err := readRandom(key)
+	if err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Get the HMAC-SHA256 of the specified passphrase (NFKD-normalized) with the cache key.
+func (cache *SeedCache) passphraseHash(passphrase string) [sha256.Size]byte {
+	var hash [sha256.Size]byte
+
+	h := hmac.New(sha256.New, cache.key)
+	h.Write([]byte(normalizeNfkd(passphrase)))
+	copy(hash[:], h.Sum(nil))
+	return hash
+}
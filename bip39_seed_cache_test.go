@@ -0,0 +1,164 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"testing"
+)
+
+//
+// Functions
+//
+
+// Test seed cache
+func TestSeedCache(t *testing.T) {
+	for _, currTest := range testVect {
+		cache, err := MnemonicFromString(currTest.Mnemonic).SeedCache()
+		if err != nil {
+			t.Fatalf("Mnemonic '%s' seed cache creation returned error: %s", currTest.Mnemonic, err.Error())
+		}
+
+		// Seed shall be the same, both when computed and when cached
+		for i := 0; i < 2; i++ {
+			seed, err := cache.GenerateSeed(testPassphrase)
+			if err != nil {
+				t.Errorf("Mnemonic '%s' cached seed generation returned error: %s", currTest.Mnemonic, err.Error())
+			} else if hex.EncodeToString(seed) != currTest.Seed {
+				t.Errorf("Mnemonic '%s' cached seed was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+			}
+			if cache.Len() != 1 {
+				t.Errorf("Mnemonic '%s' seed cache length was incorrect: expected 1, got: %d", currTest.Mnemonic, cache.Len())
+			}
+		}
+	}
+
+	mnemonic := MnemonicFromString(testVect[0].Mnemonic)
+	cache, _ := mnemonic.SeedCache()
+
+	// One seed for each passphrase, equivalent passphrases share the same seed
+	expSeed, _ := mnemonic.GenerateSeed("")
+	seed, _ := cache.GenerateSeed("")
+	if !bytes.Equal(seed, expSeed) {
+		t.Errorf("Cached seed with empty passphrase was incorrect: expected %x, got: %x", expSeed, seed)
+	}
+	cache.GenerateSeed("caf\u00e9")
+	cache.GenerateSeed("cafe\u0301")
+	if cache.Len() != 2 {
+		t.Errorf("Seed cache length was incorrect: expected 2, got: %d", cache.Len())
+	}
+
+	// Returned seed shall be a copy
+	seed[0] ^= 0xff
+	if seed, _ = cache.GenerateSeed(""); !bytes.Equal(seed, expSeed) {
+		t.Errorf("Cached seed was modified by the caller")
+	}
+	// Mnemonic modifications shall not affect the cache
+	mnemonic.Words = testVect[1].Mnemonic
+	if seed, _ = cache.GenerateSeed(""); !bytes.Equal(seed, expSeed) {
+		t.Errorf("Cached seed was modified by the mnemonic")
+	}
+
+	// Keys shall depend on the cache key, not only on the passphrase
+	otherCache, _ := mnemonic.SeedCache()
+	if bytes.Equal(cache.key, otherCache.key) || cache.passphraseHash("") == otherCache.passphraseHash("") {
+		t.Errorf("Seed caches had the same passphrase hash")
+	}
+
+	// Clear shall wipe the seeds and the key
+	var cachedSeeds [][]byte
+	for _, cachedSeed := range cache.seeds {
+		cachedSeeds = append(cachedSeeds, cachedSeed)
+	}
+	cacheKey := cache.key
+	cache.Clear()
+	if cache.key != nil || !bytes.Equal(cacheKey, make([]byte, seedCacheKeyLen)) {
+		t.Errorf("Seed cache key was not wiped")
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Seed cache was not empty after clear: got: %d", cache.Len())
+	}
+	for _, cachedSeed := range cachedSeeds {
+		if !bytes.Equal(cachedSeed, make([]byte, len(cachedSeed))) {
+			t.Errorf("Cached seed was not wiped")
+		}
+	}
+	// Cache shall be usable after clear
+	if seed, _ = cache.GenerateSeed(""); !bytes.Equal(seed, expSeed) {
+		t.Errorf("Cached seed after clear was incorrect: expected %x, got: %x", expSeed, seed)
+	}
+
+	// Errors shall not be cached
+	for _, currTest := range testVectMnemonicInvalid {
+		cache, _ := MnemonicFromString(currTest.Mnemonic).SeedCache()
+		if _, err := cache.GenerateSeed(testPassphrase); !errors.Is(err, currTest.Err) {
+			t.Errorf("Invalid mnemonic '%s' cached seed generation returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+		if cache.Len() != 0 {
+			t.Errorf("Invalid mnemonic '%s' seed cache was not empty", currTest.Mnemonic)
+		}
+	}
+}
+
+// Test seed cache creation when the random key cannot be generated
+func TestSeedCacheRandError(t *testing.T) {
+	defer SetRandReader(nil)
+
+	SetRandReader(bytes.NewReader(nil))
+	if _, err := MnemonicFromString(testVect[0].Mnemonic).SeedCache(); err == nil {
+		t.Errorf("Seed cache creation with failing random reader returned no error")
+	}
+}
+
+// Test seed cache concurrent use
+func TestSeedCacheConcurrent(t *testing.T) {
+	cache, _ := MnemonicFromString(testVect[0].Mnemonic).SeedCache()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if seed, err := cache.GenerateSeed(testPassphrase); err != nil || hex.EncodeToString(seed) != testVect[0].Seed {
+				t.Errorf("Concurrent cached seed was incorrect: got: %x (%v)", seed, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if cache.Len() != 1 {
+		t.Errorf("Concurrent seed cache length was incorrect: expected 1, got: %d", cache.Len())
+	}
+}
+
+// Benchmark cached seed generation
+func BenchmarkSeedCache(b *testing.B) {
+	cache, _ := MnemonicFromString(testVect[0].Mnemonic).SeedCache()
+	for i := 0; i < b.N; i++ {
+		cache.GenerateSeed(testPassphrase)
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains the Shamir secret sharing of mnemonic entropy for bip39 package.
+// Sharing is performed byte by byte in GF(256) (AES polynomial), so it's not compatible with SLIP-0039 mnemonics.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"errors"
+	"fmt"
+)
+
+//
+// Constants
+//
+const (
+	// Minimum threshold
+	shareMinThreshold = 2
+	// Maximum shares number (the x coordinate is a non-zero byte)
+	shareMaxNum = 255
+	// Share header length (threshold and x coordinate)
+	shareHeaderLen = 2
+	// Reduction polynomial of GF(256) (x^8 + x^4 + x^3 + x + 1)
+	gf256Poly = 0x1b
+)
+
+//
+// Variables
+//
+var (
+	// ErrShareParams is returned when trying to split entropy with invalid threshold or shares number
+	ErrShareParams = errors.New("The threshold and shares number are not valid")
+	// ErrInvalidShares is returned when trying to combine shares that are not valid or not consistent
+	ErrInvalidShares = errors.New("The shares are not valid")
+	// ErrNotEnoughShares is returned when trying to combine less shares than the threshold
+	ErrNotEnoughShares = errors.New("The shares number is lower than the threshold")
+)
+
+//
+// Exported functions
+//
+
+// Split the entropy of a mnemonic into the specified number of shares, so that any threshold of them can recover it.
+// The threshold shall be at least 2 and not greater than the shares number, which shall be at most 255.
+// Each share contains the threshold, its x coordinate and one byte for each entropy byte.
+// Error is returned if the mnemonic or the parameters are not valid.
+func (mnemonic *Mnemonic) SplitEntropy(threshold, shares int) ([][]byte, error) {
+	// Validate parameters
+	if threshold < shareMinThreshold || threshold > shares || shares > shareMaxNum {
+		return nil, fmt.Errorf("threshold %d, shares %d: %w", threshold, shares, ErrShareParams)
+	}
+
+	// Get entropy
+	entropy, err := mnemonic.ToEntropy()
+	if err != nil {
+		return nil, err
+	}
+	defer Wipe(entropy)
+
+	// Create shares with their header
+	sharesBytes := make([][]byte, shares)
+	for i := range sharesBytes {
+		sharesBytes[i] = make([]byte, shareHeaderLen + len(entropy))
+		sharesBytes[i][0] = byte(threshold)
+		sharesBytes[i][1] = byte(i + 1)
+	}
+
+	// For each entropy byte, generate a random polynomial of degree threshold - 1 having the byte as constant term
+	// and evaluate it for each share
+	coeffs := make([]byte, threshold)
+	defer Wipe(coeffs)
+	for i, b := range entropy {
+		coeffs[0] = b
+		err = readRandom(coeffs[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, share := range sharesBytes {
+			share[shareHeaderLen + i] = gf256PolyEval(coeffs, share[1])
+		}
+	}
+
+	return sharesBytes, nil
+}
+
+// Combine the specified shares, generated by SplitEntropy, to recover the entropy.
+// At least threshold shares shall be specified, otherwise ErrNotEnoughShares is returned.
+// Error is returned also if the shares are not consistent or the recovered entropy is not valid.
+func CombineEntropyShares(shares [][]byte) ([]byte, error) {
+	// Validate shares
+	err := validateShares(shares)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use only the first threshold shares
+	threshold := int(shares[0][0])
+	shares = shares[:threshold]
+
+	// Interpolate each byte at x = 0 (Lagrange)
+	entropy := make([]byte, len(shares[0]) - shareHeaderLen)
+	for i := range entropy {
+		var b byte
+		for j, share := range shares {
+			// Compute Lagrange basis at x = 0 (in GF(256) subtraction is the same of addition, i.e. xor)
+			num, den := byte(1), byte(1)
+			for k, other := range shares {
+				if k != j {
+					num = gf256Mul(num, other[1])
+					den = gf256Mul(den, other[1] ^ share[1])
+				}
+			}
+			b ^= gf256Mul(share[shareHeaderLen + i], gf256Mul(num, gf256Inv(den)))
+		}
+		entropy[i] = b
+	}
+
+	// Validate entropy bit length
+	err = validateEntropyBitLen(len(entropy) * 8)
+	if err != nil {
+		Wipe(entropy)
+		return nil, err
+	}
+
+	return entropy, nil
+}
+
+//
+// Not-exported functions
+//
+
+// Validate the specified shares.
+func validateShares(shares [][]byte) error {
+	if len(shares) == 0 || len(shares[0]) <= shareHeaderLen {
+		return fmt.Errorf("empty shares: %w", ErrInvalidShares)
+	}
+
+	// Threshold and length shall be the same for all shares, x coordinates shall be non-zero and different
+	threshold := shares[0][0]
+	xMap := make(map[byte]bool, len(shares))
+	for i, share := range shares {
+		if len(share) != len(shares[0]) || share[0] != threshold || share[1] == 0 || xMap[share[1]] {
+			return fmt.Errorf("share %d: %w", i, ErrInvalidShares)
+		}
+		xMap[share[1]] = true
+	}
+	if int(threshold) < shareMinThreshold {
+		return fmt.Errorf("threshold %d: %w", threshold, ErrInvalidShares)
+	}
+
+	// Shares number shall reach the threshold
+	if len(shares) < int(threshold) {
+		return fmt.Errorf("shares %d, threshold %d: %w", len(shares), threshold, ErrNotEnoughShares)
+	}
+	return nil
+}
+
+// Evaluate the specified polynomial (coefficients from lowest degree) at x in GF(256), using Horner's method.
+func gf256PolyEval(coeffs []byte, x byte) byte {
+	var res byte
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res = gf256Mul(res, x) ^ coeffs[i]
+	}
+	return res
+}
+
+// Multiply two elements in GF(256).
+// The number of iterations doesn't depend on the values, since they are secret.
+func gf256Mul(a, b byte) byte {
+	var res byte
+	for i := 0; i < 8; i++ {
+		// Add a if the lowest bit of b is set
+		res ^= a & -(b & 1)
+		// Multiply a by x, reducing by the polynomial if the highest bit was set
+		a = (a << 1) ^ (gf256Poly & -(a >> 7))
+		b >>= 1
+	}
+	return res
+}
+
+// Compute the multiplicative inverse of an element in GF(256), i.e. a^254.
+// The inverse of zero is zero.
+func gf256Inv(a byte) byte {
+	res := a
+	for i := 0; i < 6; i++ {
+		a = gf256Mul(a, a)
+		res = gf256Mul(res, a)
+	}
+	return gf256Mul(res, res)
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+//
+// Types
+//
+
+// Shamir parameters test vector entry structure
+type testVectShareParamsEntry struct {
+	Threshold int
+	Shares    int
+}
+
+//
+// Variables
+//
+
+// Tests for valid threshold and shares number
+var testVectShareParamsValid = []testVectShareParamsEntry {
+	testVectShareParamsEntry { Threshold: 2, Shares: 2 },
+	testVectShareParamsEntry { Threshold: 2, Shares: 3 },
+	testVectShareParamsEntry { Threshold: 3, Shares: 5 },
+	testVectShareParamsEntry { Threshold: 5, Shares: 5 },
+}
+
+// Tests for invalid threshold and shares number
+var testVectShareParamsInvalid = []testVectShareParamsEntry {
+	testVectShareParamsEntry { Threshold: 0, Shares: 3 },
+	testVectShareParamsEntry { Threshold: 1, Shares: 3 },
+	testVectShareParamsEntry { Threshold: 4, Shares: 3 },
+	testVectShareParamsEntry { Threshold: 2, Shares: 256 },
+}
+
+//
+// Functions
+//
+
+// Test splitting and combining entropy
+func TestShamirSplitCombine(t *testing.T) {
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+
+		for _, params := range testVectShareParamsValid {
+			shares, err := mnemonic.SplitEntropy(params.Threshold, params.Shares)
+			if err != nil {
+				t.Errorf("Mnemonic '%s' entropy splitting returned error: %s", currTest.Mnemonic, err.Error())
+				continue
+			}
+			if len(shares) != params.Shares {
+				t.Errorf("Shares number was incorrect: expected %d, got: %d", params.Shares, len(shares))
+				continue
+			}
+
+			// Any combination of exactly threshold shares shall recover the entropy
+			for _, subset := range testShareSubsets(shares, params.Threshold) {
+				entropy, err := CombineEntropyShares(subset)
+				if err != nil {
+					t.Errorf("Combining %d shares returned error: %s", params.Threshold, err.Error())
+					continue
+				}
+				entropyHex := hex.EncodeToString(entropy)
+				if entropyHex != currTest.Entropy {
+					t.Errorf("Combined entropy was incorrect: expected %s, got: %s", currTest.Entropy, entropyHex)
+				}
+			}
+
+			// Less than threshold shares shall fail
+			_, err = CombineEntropyShares(shares[:params.Threshold - 1])
+			if !errors.Is(err, ErrNotEnoughShares) {
+				t.Errorf("Combining %d shares with threshold %d returned wrong error (%v)", params.Threshold - 1, params.Threshold, err)
+			}
+		}
+	}
+}
+
+// Test invalid parameters and shares
+func TestShamirInvalid(t *testing.T) {
+	mnemonic := MnemonicFromString(testVect[0].Mnemonic)
+
+	// Invalid parameters
+	for _, params := range testVectShareParamsInvalid {
+		shares, err := mnemonic.SplitEntropy(params.Threshold, params.Shares)
+		if shares != nil {
+			t.Errorf("Shares with threshold %d and shares number %d were not nil", params.Threshold, params.Shares)
+		}
+		if !errors.Is(err, ErrShareParams) {
+			t.Errorf("Splitting with threshold %d and shares number %d returned wrong error (%v)", params.Threshold, params.Shares, err)
+		}
+	}
+
+	// Invalid mnemonic
+	_, err := MnemonicFromString(testVectMnemonicInvalid[0].Mnemonic).SplitEntropy(2, 3)
+	if !errors.Is(err, testVectMnemonicInvalid[0].Err) {
+		t.Errorf("Splitting invalid mnemonic returned wrong error (%v)", err)
+	}
+
+	// Inconsistent shares
+	shares, _ := mnemonic.SplitEntropy(2, 3)
+	invalidShares := [][][]byte {
+		nil,
+		[][]byte { shares[0], shares[0] },
+		[][]byte { shares[0], shares[1][:len(shares[1]) - 1] },
+		[][]byte { shares[0], append([]byte { 3 }, shares[1][1:]...) },
+		[][]byte { shares[0], append([]byte { 2, 0 }, shares[1][2:]...) },
+		[][]byte { shares[0][:2], shares[1][:2] },
+	}
+	for _, currShares := range invalidShares {
+		if _, err := CombineEntropyShares(currShares); !errors.Is(err, ErrInvalidShares) {
+			t.Errorf("Combining invalid shares returned wrong error (%v)", err)
+		}
+	}
+
+	// Shares of invalid entropy length
+	_, err = CombineEntropyShares([][]byte { shares[0][:10], shares[1][:10] })
+	if !errors.Is(err, ErrEntropyBitLen) {
+		t.Errorf("Combining shares of invalid length returned wrong error (%v)", err)
+	}
+}
+
+// Test GF(256) arithmetic
+func TestGf256(t *testing.T) {
+	// Known product of the AES field
+	if res := gf256Mul(0x57, 0x83); res != 0xc1 {
+		t.Errorf("GF(256) multiplication was incorrect: expected %x, got: %x", 0xc1, res)
+	}
+	// Each non-zero element multiplied by its inverse shall be one
+	for a := 1; a < 256; a++ {
+		if res := gf256Mul(byte(a), gf256Inv(byte(a))); res != 1 {
+			t.Errorf("GF(256) inverse of %x was incorrect", a)
+		}
+	}
+	if res := gf256Inv(0); res != 0 {
+		t.Errorf("GF(256) inverse of zero was incorrect: expected 0, got: %x", res)
+	}
+}
+
+// Get all the subsets of the specified size of the shares.
+func testShareSubsets(shares [][]byte, size int) [][][]byte {
+	if size == 0 {
+		return [][][]byte { nil }
+	}
+	if len(shares) < size {
+		return nil
+	}
+
+	// Subsets including the first share, then the ones excluding it
+	var subsets [][][]byte
+	for _, subset := range testShareSubsets(shares[1:], size - 1) {
+		subsets = append(subsets, append([][]byte { shares[0] }, subset...))
+	}
+	return append(subsets, testShareSubsets(shares[1:], size)...)
+}
@@ -24,9 +24,23 @@ package bip39
 // Imports
 //
 import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"io/ioutil"
+	"reflect"
+	"strconv"
 	"strings"
 	"testing"
+	"testing/iotest"
+	"golang.org/x/text/unicode/norm"
 )
 
 //
@@ -46,6 +60,33 @@ type testVectInvalidMnemonicEntry struct {
 	Err      error
 }
 
+// Mnemonic normalization test vector entry structure
+type testVectMnemonicNormalizeEntry struct {
+	Mnemonic   string
+	Normalized string
+}
+
+// Mnemonic comparison test vector entry structure
+type testVectMnemonicEqualEntry struct {
+	Mnemonic1 string
+	Mnemonic2 string
+	Equal     bool
+}
+
+// Dice rolls test vector entry structure
+type testVectDiceEntry struct {
+	Rolls   string
+	BitLen  int
+	Entropy string
+}
+
+// Deterministic mnemonic test vector entry structure
+type testVectDeterministicEntry struct {
+	Label    string
+	BitLen   int
+	Mnemonic string
+}
+
 //
 // Constants
 //
@@ -54,6 +95,42 @@ const (
 	testPassphrase = "TREZOR"
 )
 
+// Mnemonic redaction test vector entry structure
+type testVectMnemonicRedactEntry struct {
+	Mnemonic  string
+	ShowFirst int
+	ShowLast  int
+	Redacted  string
+}
+
+// Fingerprint test vector entry structure
+type testVectFingerprintEntry struct {
+	Mnemonic    string
+	Passphrase  string
+	Fingerprint string
+}
+
+// Seed without passphrase test vector entry structure
+type testVectSeedNoPassphraseEntry struct {
+	Mnemonic string
+	Seed     string
+}
+
+// Extended entropy test vector entry structure
+type testVectExtendEntropyEntry struct {
+	Mnemonic string
+	WordsNum int
+	Extended string
+}
+
+// Seed with salt test vector entry structure
+type testVectSeedWithSaltEntry struct {
+	Mnemonic   string
+	SaltPrefix string
+	Rounds     int
+	Seed       string
+}
+
 //
 // Variables
 //
@@ -190,6 +267,49 @@ var testVect = []testVectEntry {
     },
 }
 
+// Tests for entropy from dice rolls
+var testVectDice = []testVectDiceEntry {
+	testVectDiceEntry {
+		Rolls:   "111111111111111111111111111111111111111111111111111111111111111111111111111",
+		BitLen:  EntropyBits128,
+		Entropy: "00000000000000000000000000000000",
+	},
+	testVectDiceEntry {
+		Rolls:   "111111111111111111111111166666666666666666666666666666666666666666666666666",
+		BitLen:  EntropyBits128,
+		Entropy: "60154fc36cbf42778f23ffffffffffff",
+	},
+	testVectDiceEntry {
+		Rolls:   "11111 11111 11111 11111 11111 12345 61234 56123 45612 34561 23456 12345 61234 56123 45612",
+		BitLen:  EntropyBits128,
+		Entropy: "184ec4bed56eb86aacaaa224b5672f45",
+	},
+	testVectDiceEntry {
+		Rolls:   "1111111111111111111111116543213654321365432136543213654321365432136543213654321365432136543213654321365432136543213654321365",
+		BitLen:  EntropyBits256,
+		Entropy: "6aa4707b78c40d5b2e06a7f836ed62dbe5e88b507ed0b5647b77dc4a6a1ec3fa",
+	},
+}
+
+// Tests for deterministic mnemonic generation
+var testVectDeterministic = []testVectDeterministicEntry {
+	testVectDeterministicEntry {
+		Label:    "test",
+		BitLen:   EntropyBits128,
+		Mnemonic: "panel custom call awesome sick ready hamster wool patch client reduce clay",
+	},
+	testVectDeterministicEntry {
+		Label:    "test",
+		BitLen:   EntropyBits256,
+		Mnemonic: "panel custom call awesome sick ready hamster wool patch client reduce clip desk pole hole gesture lion grief firm subway force job choice bargain",
+	},
+	testVectDeterministicEntry {
+		Label:    "wallet fixture",
+		BitLen:   EntropyBits192,
+		Mnemonic: "edge smart ancient rabbit dinner toilet track brief slight whisper letter demand beach crouch hard weasel tool border",
+	},
+}
+
 // Test for valid words number
 var testVectWordsNumValid = []int {
 	WordsNum12,
@@ -199,6 +319,77 @@ var testVectWordsNumValid = []int {
 	WordsNum24,
 }
 
+// Tests for mnemonic fingerprint
+var testVectFingerprint = []testVectFingerprintEntry {
+	testVectFingerprintEntry {
+		Mnemonic:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Passphrase:  "TREZOR",
+		Fingerprint: "dacdbe49",
+	},
+	testVectFingerprintEntry {
+		Mnemonic:    "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Passphrase:  "TREZOR",
+		Fingerprint: "a2419128",
+	},
+	// Different passphrase, different fingerprint
+	testVectFingerprintEntry {
+		Mnemonic:    "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Passphrase:  "",
+		Fingerprint: "9c035a94",
+	},
+}
+
+// Tests for seed generation with empty passphrase (i.e. salt "mnemonic" only)
+var testVectSeedNoPassphrase = []testVectSeedNoPassphraseEntry {
+	testVectSeedNoPassphraseEntry {
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		Seed:     "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4",
+	},
+	testVectSeedNoPassphraseEntry {
+		Mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Seed:     "878386efb78845b3355bd15ea4d39ef97d179cb712b77d5c12b6be415fffeffe5f377ba02bf3f8544ab800b955e51fbff09828f682052a20faa6addbbddfb096",
+	},
+	testVectSeedNoPassphraseEntry {
+		Mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+		Seed:     "e28a37058c7f5112ec9e16a3437cf363a2572d70b6ceb3b6965447623d620f14d06bb321a26b33ec15fcd84a3b5ddfd5520e230c924c87aaa0d559749e044fef",
+	},
+}
+
+// Tests for mnemonic entropy extension
+var testVectExtendEntropy = []testVectExtendEntropyEntry {
+	testVectExtendEntropyEntry {
+		Mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		WordsNum: WordsNum24,
+		Extended: "legal winner thank year wave sausage worth useful legal winner thank yellow lava hundred write swim catalog april try inside razor auto shallow absent",
+	},
+	testVectExtendEntropyEntry {
+		Mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		WordsNum: WordsNum15,
+		Extended: "legal winner thank year wave sausage worth useful legal winner thank yellow lava hundred wrap",
+	},
+	testVectExtendEntropyEntry {
+		Mnemonic: "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always",
+		WordsNum: WordsNum24,
+		Extended: "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always digital moment fresh crop trim arctic",
+	},
+}
+
+// Tests for seed generation with custom salt prefix and rounds (passphrase: TREZOR)
+var testVectSeedWithSalt = []testVectSeedWithSaltEntry {
+	testVectSeedWithSaltEntry {
+		Mnemonic:   "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		SaltPrefix: "custom",
+		Rounds:     2048,
+		Seed:       "43ad825b8856f8e5c0442440350bc240711c38b01ace7c75846b16875a7cb89bc3a7f7fe075e5fcd10aeed1c32642e11891822439465e856d7d7a59a86890c17",
+	},
+	testVectSeedWithSaltEntry {
+		Mnemonic:   "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		SaltPrefix: "custom",
+		Rounds:     1,
+		Seed:       "b6ff8600ebfa0541bf01e08700aaf9b8f3957e6733e38a42f054d09de268f69461a12ac115f2f86412bbbf970a66bbfbdece8da5e30fa05c51d538d57797d2d2",
+	},
+}
+
 // Test for invalid words number
 var testVectWordsNumInvalid = []int {
 	11,
@@ -249,6 +440,111 @@ var testVectMnemonicInvalid = []testVectInvalidMnemonicEntry {
 	},
 }
 
+// Tests for mnemonic comparison
+var testVectMnemonicEqual = []testVectMnemonicEqualEntry {
+	testVectMnemonicEqualEntry {
+		Mnemonic1: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Mnemonic2: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Equal:     true,
+	},
+	// Different whitespaces
+	testVectMnemonicEqualEntry {
+		Mnemonic1: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Mnemonic2: " legal  winner thank\tyear wave sausage worth useful legal winner thank yellow\n",
+		Equal:     true,
+	},
+	// Different words
+	testVectMnemonicEqualEntry {
+		Mnemonic1: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Mnemonic2: "legal winner thank year wave sausage worth useful legal winner thank wrong",
+		Equal:     false,
+	},
+	// Different lengths
+	testVectMnemonicEqualEntry {
+		Mnemonic1: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Mnemonic2: "legal winner thank year wave sausage worth useful legal winner thank",
+		Equal:     false,
+	},
+	testVectMnemonicEqualEntry {
+		Mnemonic1: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Mnemonic2: "",
+		Equal:     false,
+	},
+}
+
+// Tests for mnemonic normalization
+var testVectMnemonicNormalize = []testVectMnemonicNormalizeEntry {
+	testVectMnemonicNormalizeEntry {
+		Mnemonic:   "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Normalized: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+	},
+	// Leading and trailing spaces
+	testVectMnemonicNormalizeEntry {
+		Mnemonic:   "  legal winner thank year wave sausage worth useful legal winner thank yellow ",
+		Normalized: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+	},
+	// Multiple spaces, tabs and new lines
+	testVectMnemonicNormalizeEntry {
+		Mnemonic:   "legal  winner\tthank year\nwave sausage \t worth useful legal winner thank yellow",
+		Normalized: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+	},
+	// Uppercase
+	testVectMnemonicNormalizeEntry {
+		Mnemonic:   "LEGAL Winner thank year wave sausage worth useful legal winner thank yelloW",
+		Normalized: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+	},
+}
+
+// Tests for mnemonic redaction
+var testVectMnemonicRedact = []testVectMnemonicRedactEntry {
+	testVectMnemonicRedactEntry {
+		Mnemonic:  "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		ShowFirst: 1,
+		ShowLast:  1,
+		Redacted:  "abandon **** **** **** **** **** **** **** **** **** **** about",
+	},
+	testVectMnemonicRedactEntry {
+		Mnemonic:  " legal winner thank year  wave sausage worth useful legal winner thank yellow ",
+		ShowFirst: 2,
+		ShowLast:  0,
+		Redacted:  "legal winner **** **** **** **** **** **** **** **** **** ****",
+	},
+	testVectMnemonicRedactEntry {
+		Mnemonic:  "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		ShowFirst: -1,
+		ShowLast:  3,
+		Redacted:  "**** **** **** **** **** **** **** **** **** winner thank yellow",
+	},
+	testVectMnemonicRedactEntry {
+		Mnemonic:  "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		ShowFirst: 0,
+		ShowLast:  0,
+		Redacted:  "**** **** **** **** **** **** **** **** **** **** **** ****",
+	},
+	// Words to keep exceeding the mnemonic words
+	testVectMnemonicRedactEntry {
+		Mnemonic:  "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		ShowFirst: 6,
+		ShowLast:  6,
+		Redacted:  "legal winner thank year wave sausage worth useful legal winner thank yellow",
+	},
+	testVectMnemonicRedactEntry {
+		Mnemonic:  "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		ShowFirst: 10,
+		ShowLast:  10,
+		Redacted:  "legal winner thank year wave sausage worth useful legal winner thank yellow",
+	},
+}
+
+// Tests for JSON encoding
+var testVectMnemonicJSON = []string {
+	"legal winner thank year wave sausage worth useful legal winner thank yellow",
+	// Japanese separator (U+3000)
+	"あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+	// Invalid mnemonic, not validated
+	"notexistent words",
+}
+
 // Tests for invalid binary strings
 var testBinaryStringInvalid = []string {
 	// Invalid lengths
@@ -309,11 +605,28 @@ func TestVector(t *testing.T) {
 			t.Errorf("Mnemonic '%s' seed generation was incorrect: expected %s, got: %s", currTest.Mnemonic, currTest.Seed, seed_hex)
 		}
 
+		// Generate seed reader from mnemonic
+		seedReader, err := mnemonic.GenerateSeedReader(testPassphrase)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed reader generation returned error: %s", currTest.Mnemonic, err.Error())
+		} else {
+			seed, _ = ioutil.ReadAll(seedReader)
+			if hex.EncodeToString(seed) != currTest.Seed {
+				t.Errorf("Mnemonic '%s' seed reader was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+			}
+		}
+
 		// Create mnemonic from string
 		mnemonic = MnemonicFromString(currTest.Mnemonic)
 		if mnemonic.Words != currTest.Mnemonic {
 			t.Errorf("Mnemonic from string was incorrect: expected %s, got: %s", currTest.Mnemonic, mnemonic.Words)
 		}
+
+		// Get mnemonic as string
+		mnemonic_str := fmt.Sprint(mnemonic)
+		if mnemonic_str != currTest.Mnemonic {
+			t.Errorf("Mnemonic to string was incorrect: expected %s, got: %s", currTest.Mnemonic, mnemonic_str)
+		}
 	}
 }
 
@@ -330,6 +643,20 @@ func TestWordsNumValid(t *testing.T) {
 		if err != nil {
 			t.Errorf("Mnemonic from valid words number (%d) returned error: %s", testWordsNum, err.Error())
 		}
+
+		// Check the words count
+		if mnemonic.WordCount() != testWordsNum {
+			t.Errorf("Mnemonic words count was incorrect: expected %d, got: %d", testWordsNum, mnemonic.WordCount())
+		}
+
+		// Check the entropy bit length, it shall be the same of the entropy got back
+		entropyBitLen, err := mnemonic.EntropyBitLen()
+		entropy, _ := mnemonic.ToEntropy()
+		if err != nil {
+			t.Errorf("Mnemonic entropy bit length (%d words) returned error: %s", testWordsNum, err.Error())
+		} else if entropyBitLen != len(entropy) * 8 {
+			t.Errorf("Mnemonic entropy bit length was incorrect: expected %d, got: %d", len(entropy) * 8, entropyBitLen)
+		}
 	}
 }
 
@@ -342,9 +669,16 @@ func TestWordsNumInvalid(t *testing.T) {
 		if mnemonic != nil {
 			t.Errorf("Mnemonic from invalid words number (%d) was not nil", testWordsNum)
 		}
-		if err != ErrWordsNum {
+		if !errors.Is(err, ErrWordsNum) {
 			t.Errorf("Mnemonic from invalid words number (%d) returned wrong error (%s)", testWordsNum, err.Error())
 		}
+
+		// Entropy bit length shall return error
+		mnemonic = MnemonicFromString(strings.TrimSpace(strings.Repeat("abandon ", testWordsNum)))
+		_, err = mnemonic.EntropyBitLen()
+		if !errors.Is(err, ErrWordsNum) {
+			t.Errorf("Mnemonic entropy bit length from invalid words number (%d) returned wrong error (%v)", testWordsNum, err)
+		}
 	}
 }
 
@@ -373,7 +707,7 @@ func TestEntropyBitLenInvalid(t *testing.T) {
 		if entropy != nil {
 			t.Errorf("Entropy from invalid bit length (%d) was not nil", testBitLen)
 		}
-		if err != ErrEntropyBitLen {
+		if !errors.Is(err, ErrEntropyBitLen) {
 			t.Errorf("Entropy from invalid bit length (%d) returned wrong error (%s)", testBitLen, err.Error())
 		}
 
@@ -386,57 +720,2285 @@ func TestEntropyBitLenInvalid(t *testing.T) {
 		if mnemonic != nil {
 			t.Errorf("Mnemonic from invalid entropy bit length (%d) was not nil", testBitLen)
 		}
-		if err != ErrEntropyBitLen {
+		if !errors.Is(err, ErrEntropyBitLen) {
 			t.Errorf("Mnemonic from invalid entropy bit length (%d) returned wrong error (%s)", testBitLen, err.Error())
 		}
 	}
 }
 
-// Test invalid mnemonics
-func TestMnemonicInvalid(t *testing.T) {
-	for _, testEntry := range testVectMnemonicInvalid {
-		// Create mnemonic from string
-		mnemonic := MnemonicFromString(testEntry.Mnemonic)
-		// Validate mnemonic, shall return error
-		err := mnemonic.Validate()
-		if err != testEntry.Err {
-			t.Errorf("Invalid mnemonic '%s' validation returned wrong error (%s)", testEntry.Mnemonic, err.Error())
+// Test entropy byte length validity
+func TestEntropyLenValid(t *testing.T) {
+	// Valid lengths
+	byteLens := ValidEntropyByteLens()
+	if !reflect.DeepEqual(byteLens, []int { 16, 20, 24, 28, 32 }) {
+		t.Errorf("Valid entropy byte lengths were incorrect: got: %v", byteLens)
+	}
+	for _, testBitLen := range testVectEntropyBitLenValid {
+		if !IsValidEntropyLen(make([]byte, testBitLen / 8)) {
+			t.Errorf("Entropy byte length (%d) was not valid", testBitLen / 8)
 		}
+	}
 
-		// Get entropy back from mnemonic
-		entropy, err := mnemonic.ToEntropy()
-		// Generated entropy shall be nil and error shall be not nil
-		if entropy != nil {
-			t.Errorf("Entropy from invalid mnemonic (%s) was not nil", testEntry.Mnemonic)
+	// Invalid lengths
+	for _, byteLen := range []int { 0, 15, 17, 33, 64 } {
+		if IsValidEntropyLen(make([]byte, byteLen)) {
+			t.Errorf("Invalid entropy byte length (%d) was valid", byteLen)
 		}
-		if err != testEntry.Err {
-			t.Errorf("Entropy from invalid mnemonic (%s) returned wrong error (%s)", testEntry.Mnemonic, err.Error())
+	}
+
+	// The returned slice shall be a copy
+	byteLens[0] = 0
+	if ValidEntropyByteLens()[0] != 16 {
+		t.Errorf("Valid entropy byte lengths were modified by the caller")
+	}
+}
+
+// Test entropy checksum
+func TestEntropyChecksum(t *testing.T) {
+	for _, currTest := range testVect {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+
+		chksum, chksumBitLen, err := EntropyChecksum(entropy)
+		if err != nil {
+			t.Errorf("Entropy %s checksum returned error: %s", currTest.Entropy, err.Error())
+			continue
+		}
+		if expBitLen := len(entropy) * 8 / 32; chksumBitLen != expBitLen {
+			t.Errorf("Entropy %s checksum bit length was incorrect: expected %d, got: %d", currTest.Entropy, expBitLen, chksumBitLen)
 		}
 
-		// Generate seed from mnemonic
-		seed, err := mnemonic.GenerateSeed(testPassphrase)
-		// Generated seed shall be nil and error shall be not nil
-		if seed != nil {
-			t.Errorf("Seed from invalid mnemonic (%s) was not nil", testEntry.Mnemonic)
+		// The checksum shall be the lowest bits of the last word index
+		words := strings.Split(currTest.Mnemonic, " ")
+		expChksum := byte(languageWordsListMap[LangEnglish].wordIndex(words[len(words) - 1]) & ((1 << uint(chksumBitLen)) - 1))
+		if len(chksum) != 1 || chksum[0] != expChksum {
+			t.Errorf("Entropy %s checksum was incorrect: expected %x, got: %x", currTest.Entropy, expChksum, chksum)
 		}
-		if err != testEntry.Err {
-			t.Errorf("Seed from invalid mnemonic (%s) returned wrong error (%s)", testEntry.Mnemonic, err.Error())
+	}
+
+	// Invalid entropy
+	for _, testBitLen := range testVectEntropyBitLenInvalid {
+		_, _, err := EntropyChecksum(make([]byte, (testBitLen - 8) / 8))
+		if !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Checksum of invalid entropy bit length (%d) returned wrong error (%v)", testBitLen, err)
 		}
 	}
 }
 
-// Test invalid binary strings
-// Valid strings are implicitly tested in the test vector
-func TestBinaryStringInvalid(t *testing.T) {
-	for _, testBinStr := range testBinaryStringInvalid {
-		// Convert binary string to bytes
-		slice, err := binaryStringToBytes(testBinStr)
-		// Byte slice shall be nil and error shall be not nil
-		if slice != nil {
-			t.Errorf("Invalid binary string (%s) conversion byte slice was not nil", testBinStr)
+// Test mnemonic from word indexes
+func TestWordIndices(t *testing.T) {
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+
+		// Get word indexes
+		wordsIdx, err := mnemonic.WordIndices(LangEnglish)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' word indexes returned error: %s", currTest.Mnemonic, err.Error())
+			continue
+		}
+
+		// Convert them back to mnemonic
+		mnemonicBack, err := MnemonicFromWordIndices(wordsIdx, LangEnglish)
+		if err != nil {
+			t.Errorf("Mnemonic from word indexes %v returned error: %s", wordsIdx, err.Error())
+			continue
+		}
+		if mnemonicBack.Words != currTest.Mnemonic {
+			t.Errorf("Mnemonic from word indexes was incorrect: expected %s, got: %s", currTest.Mnemonic, mnemonicBack.Words)
+		}
+	}
+
+	// Invalid indexes ("abandon ... about" is 0, ..., 0, 3)
+	invalidIdx := map[error][]int {
+		ErrWordsNum:  []int { 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3 },
+		ErrWordIndex: []int { 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, -1, 3 },
+		ErrChecksum:  []int { 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4 },
+	}
+	for expErr, wordsIdx := range invalidIdx {
+		if _, err := MnemonicFromWordIndices(wordsIdx, LangEnglish); !errors.Is(err, expErr) {
+			t.Errorf("Mnemonic from invalid word indexes %v returned wrong error (%v)", wordsIdx, err)
+		}
+	}
+	if _, err := MnemonicFromWordIndices([]int { 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2048 }, LangEnglish); !errors.Is(err, ErrWordIndex) {
+		t.Errorf("Mnemonic from out of range word index returned wrong error (%v)", err)
+	}
+	if _, err := MnemonicFromWordIndices([]int { 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3 }, Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Mnemonic from word indexes with unsupported language returned wrong error (%v)", err)
+	}
+
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if currTest.Err == ErrChecksum {
+			continue
+		}
+		if _, err := MnemonicFromString(currTest.Mnemonic).WordIndices(LangEnglish); !errors.Is(err, currTest.Err) {
+			t.Errorf("Word indexes of invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
+
+// Test conversion between entropy and word indexes
+func TestEntropyIndices(t *testing.T) {
+	var testMnemonics []*Mnemonic
+	for _, currTest := range testVect {
+		testMnemonics = append(testMnemonics, MnemonicFromString(currTest.Mnemonic))
+	}
+	// Cover all sizes
+	for _, testBitLen := range testVectEntropyBitLenValid {
+		mnemonic, _ := MnemonicFromDeterministicSeed("indices", testBitLen)
+		testMnemonics = append(testMnemonics, mnemonic)
+	}
+
+	for _, mnemonic := range testMnemonics {
+		entropy, _ := mnemonic.ToEntropy()
+		expIndices, _ := mnemonic.WordIndices(LangEnglish)
+
+		// Entropy to indexes
+		indices, err := EntropyToIndices(entropy)
+		if err != nil {
+			t.Errorf("Entropy %x to indexes returned error: %s", entropy, err.Error())
+		} else if !reflect.DeepEqual(indices, expIndices) {
+			t.Errorf("Entropy %x to indexes was incorrect: expected %v, got: %v", entropy, expIndices, indices)
+		}
+
+		// Indexes to entropy
+		outEntropy, err := IndicesToEntropy(expIndices)
+		if err != nil {
+			t.Errorf("Indexes %v to entropy returned error: %s", expIndices, err.Error())
+		} else if !bytes.Equal(outEntropy, entropy) {
+			t.Errorf("Indexes %v to entropy was incorrect: expected %x, got: %x", expIndices, entropy, outEntropy)
+		}
+	}
+
+	// Invalid entropy bit length
+	for _, testByteLen := range []int { 0, 15, 17, 33 } {
+		if _, err := EntropyToIndices(make([]byte, testByteLen)); !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Entropy with invalid length (%d) to indexes returned wrong error (%v)", testByteLen, err)
+		}
+	}
+	// Invalid indexes ("abandon ... about" is 0, ..., 0, 3)
+	invalidIdx := map[error][]int {
+		ErrWordsNum:  []int { 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3 },
+		ErrWordIndex: []int { 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2048 },
+		ErrChecksum:  []int { 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 4 },
+	}
+	for expErr, indices := range invalidIdx {
+		if _, err := IndicesToEntropy(indices); !errors.Is(err, expErr) {
+			t.Errorf("Invalid indexes %v to entropy returned wrong error (%v)", indices, err)
+		}
+	}
+	if _, err := IndicesToEntropy([]int { -1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 3 }); !errors.Is(err, ErrWordIndex) {
+		t.Errorf("Negative index to entropy returned wrong error (%v)", err)
+	}
+}
+
+// Test mnemonics with mixed separators, which shall be accepted independently from the language
+func TestMixedSeparators(t *testing.T) {
+	separators := []string { " ", "\u3000", "\t", "\n", "\r\n", " \u3000 " }
+
+	for _, currTest := range testVect {
+		// Use a different separator for each word
+		words := strings.Fields(currTest.Mnemonic)
+		mixedStr := ""
+		for i, word := range words {
+			mixedStr += word + separators[i % len(separators)]
+		}
+
+		for _, mnemonic := range []*Mnemonic { MnemonicFromStringRaw(mixedStr), MnemonicFromString(mixedStr), MnemonicFromStringLang(mixedStr, LangEnglish) } {
+			if err := mnemonic.Validate(); err != nil {
+				t.Errorf("Mnemonic %q with mixed separators validation returned error: %s", mixedStr, err.Error())
+				continue
+			}
+
+			entropy, _ := mnemonic.ToEntropy()
+			if hex.EncodeToString(entropy) != currTest.Entropy {
+				t.Errorf("Mnemonic %q with mixed separators entropy was incorrect: expected %s, got: %x", mixedStr, currTest.Entropy, entropy)
+			}
+			buff := make([]byte, EntropyBits256 / 8)
+			n, err := mnemonic.ToEntropyInto(buff)
+			if err != nil || hex.EncodeToString(buff[:n]) != currTest.Entropy {
+				t.Errorf("Mnemonic %q with mixed separators entropy into buffer was incorrect: expected %s, got: %x (%v)", mixedStr, currTest.Entropy, buff[:n], err)
+			}
+			seed, _ := mnemonic.GenerateSeedHex(testPassphrase)
+			if seed != currTest.Seed {
+				t.Errorf("Mnemonic %q with mixed separators seed was incorrect: expected %s, got: %s", mixedStr, currTest.Seed, seed)
+			}
+		}
+	}
+}
+
+// Test mnemonic breakdown
+func TestBreakdown(t *testing.T) {
+	for _, currTest := range testVect {
+		wordsInfo, err := MnemonicFromString(currTest.Mnemonic).Breakdown(LangEnglish)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' breakdown returned error: %s", currTest.Mnemonic, err.Error())
+			continue
+		}
+
+		words := strings.Fields(currTest.Mnemonic)
+		bitsStr := ""
+		for i, wordInfo := range wordsInfo {
+			if wordInfo.Word != words[i] || wordInfo.Index != languageWordsListMap[LangEnglish].wordIndex(words[i]) || len(wordInfo.Bits) != WordBitLen {
+				t.Errorf("Mnemonic '%s' breakdown of word %d was incorrect: got: %+v", currTest.Mnemonic, i, wordInfo)
+			}
+			if wordInfo.IsChecksumBoundary != (i == len(words) - 1) {
+				t.Errorf("Mnemonic '%s' checksum boundary of word %d was incorrect", currTest.Mnemonic, i)
+			}
+			bitsStr += wordInfo.Bits
+		}
+
+		// Bits shall be entropy followed by checksum
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+		chksum, chksumBitLen, _ := EntropyChecksum(entropy)
+		expBitsStr := bytesToBinaryString(entropy) + fmt.Sprintf("%0*b", chksumBitLen, chksum[0])
+		if bitsStr != expBitsStr {
+			t.Errorf("Mnemonic '%s' breakdown bits were incorrect: expected %s, got: %s", currTest.Mnemonic, expBitsStr, bitsStr)
+		}
+	}
+
+	// Invalid checksum shall be accepted
+	if _, err := MnemonicFromString("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon").Breakdown(LangEnglish); err != nil {
+		t.Errorf("Breakdown of mnemonic with invalid checksum returned error: %s", err.Error())
+	}
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if currTest.Err == ErrChecksum {
+			continue
+		}
+		if _, err := MnemonicFromString(currTest.Mnemonic).Breakdown(LangEnglish); !errors.Is(err, currTest.Err) {
+			t.Errorf("Breakdown of invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+	// Unsupported language
+	if _, err := MnemonicFromString(testVect[0].Mnemonic).Breakdown(Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Breakdown with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test checksum bit errors
+func TestChecksumBitErrors(t *testing.T) {
+	for _, currTest := range testVect {
+		bitErrs, err := MnemonicFromString(currTest.Mnemonic).ChecksumBitErrors()
+		if err != nil {
+			t.Errorf("Mnemonic '%s' checksum bit errors returned error: %s", currTest.Mnemonic, err.Error())
+		} else if bitErrs != 0 {
+			t.Errorf("Mnemonic '%s' checksum bit errors was incorrect: expected 0, got: %d", currTest.Mnemonic, bitErrs)
+		}
+	}
+
+	// Wrong checksum (expected checksum of "abandon ... about" is 0011)
+	wrongChksum := map[string]int {
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon ability": 1,
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon able":    1,
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon above":   3,
+	}
+	for mnemonicStr, expBitErrs := range wrongChksum {
+		bitErrs, err := MnemonicFromString(mnemonicStr).ChecksumBitErrors()
+		if err != nil {
+			t.Errorf("Mnemonic '%s' checksum bit errors returned error: %s", mnemonicStr, err.Error())
+		} else if bitErrs != expBitErrs {
+			t.Errorf("Mnemonic '%s' checksum bit errors was incorrect: expected %d, got: %d", mnemonicStr, expBitErrs, bitErrs)
+		}
+	}
+
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if currTest.Err == ErrChecksum {
+			continue
+		}
+		if _, err := MnemonicFromString(currTest.Mnemonic).ChecksumBitErrors(); !errors.Is(err, currTest.Err) {
+			t.Errorf("Checksum bit errors of invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
+
+// Test words slice
+func TestWordSlice(t *testing.T) {
+	testWords := map[string][]string {
+		"":                      []string {},
+		"abandon":               []string { "abandon" },
+		"abandon ability able":  []string { "abandon", "ability", "able" },
+		" abandon  ability   ":  []string { "abandon", "ability" },
+		"abandon\tability\n":    []string { "abandon", "ability" },
+		" ":                     []string {},
+		" \t\n ":                []string {},
+		"abandon\u3000ability":  []string { "abandon", "ability" },
+	}
+	for testStr, expWords := range testWords {
+		mnemonic := MnemonicFromStringRaw(testStr)
+		words := mnemonic.WordSlice()
+		if strings.Join(words, ",") != strings.Join(expWords, ",") {
+			t.Errorf("Mnemonic '%s' words slice was incorrect: expected %v, got: %v", testStr, expWords, words)
+		}
+		if mnemonic.WordCount() != len(expWords) {
+			t.Errorf("Mnemonic '%s' words count was incorrect: expected %d, got: %d", testStr, len(expWords), mnemonic.WordCount())
+		}
+	}
+
+	// A new slice shall be returned at each call
+	mnemonic := MnemonicFromString(testVect[0].Mnemonic)
+	mnemonic.WordSlice()[0] = "modified"
+	if mnemonic.WordSlice()[0] == "modified" || !mnemonic.IsValid() {
+		t.Errorf("Mnemonic words slice modification changed the mnemonic")
+	}
+}
+
+// Test deterministic mnemonic generation
+func TestDeterministicSeed(t *testing.T) {
+	for _, currTest := range testVectDeterministic {
+		// Generate it twice, result shall be the same
+		for i := 0; i < 2; i++ {
+			mnemonic, err := MnemonicFromDeterministicSeed(currTest.Label, currTest.BitLen)
+			if err != nil {
+				t.Errorf("Mnemonic from label '%s' returned error: %s", currTest.Label, err.Error())
+			} else if mnemonic.Words != currTest.Mnemonic {
+				t.Errorf("Mnemonic from label '%s' was incorrect: expected %s, got: %s", currTest.Label, currTest.Mnemonic, mnemonic.Words)
+			}
 		}
-		if err == nil {
-			t.Errorf("Invalid binary string (%s) conversion returned no error", testBinStr)
+	}
+
+	// Invalid bit length
+	for _, testBitLen := range testVectEntropyBitLenInvalid {
+		if _, err := MnemonicFromDeterministicSeed("test", testBitLen); !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Mnemonic from label with invalid bit length (%d) returned wrong error (%v)", testBitLen, err)
+		}
+	}
+}
+
+// Test seed generation as hex and base64
+func TestSeedEncoding(t *testing.T) {
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+
+		seedHex, err := mnemonic.GenerateSeedHex(testPassphrase)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' hex seed generation returned error: %s", currTest.Mnemonic, err.Error())
+		} else if seedHex != currTest.Seed {
+			t.Errorf("Mnemonic '%s' hex seed was incorrect: expected %s, got: %s", currTest.Mnemonic, currTest.Seed, seedHex)
+		}
+
+		seedBytes, _ := hex.DecodeString(currTest.Seed)
+		expSeedBase64 := base64.StdEncoding.EncodeToString(seedBytes)
+		seedBase64, err := mnemonic.GenerateSeedBase64(testPassphrase)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' base64 seed generation returned error: %s", currTest.Mnemonic, err.Error())
+		} else if seedBase64 != expSeedBase64 {
+			t.Errorf("Mnemonic '%s' base64 seed was incorrect: expected %s, got: %s", currTest.Mnemonic, expSeedBase64, seedBase64)
+		}
+	}
+
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+		if seedHex, err := mnemonic.GenerateSeedHex(testPassphrase); seedHex != "" || !errors.Is(err, currTest.Err) {
+			t.Errorf("Hex seed from invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+		if seedBase64, err := mnemonic.GenerateSeedBase64(testPassphrase); seedBase64 != "" || !errors.Is(err, currTest.Err) {
+			t.Errorf("Base64 seed from invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
+
+// Test seed generation with empty passphrase
+// The salt shall be "mnemonic" followed by the passphrase, so the empty passphrase and the test vectors one ("TREZOR") give different seeds
+func TestSeedNoPassphrase(t *testing.T) {
+	for _, currTest := range testVectSeedNoPassphrase {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+		seed, err := mnemonic.GenerateSeed("")
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed generation with empty passphrase returned error: %s", currTest.Mnemonic, err.Error())
+		} else if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Seed generation with empty passphrase was incorrect: expected %s, got: %x", currTest.Seed, seed)
+		}
+
+		// Same salt when using custom salt prefix
+		seed, _ = mnemonic.GenerateSeedWithSalt("", "mnemonic", 2048)
+		if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Seed generation with empty passphrase and salt was incorrect: expected %s, got: %x", currTest.Seed, seed)
 		}
 	}
+
+	// Same mnemonics with test vectors passphrase
+	for _, currTest := range testVect {
+		for _, noPassTest := range testVectSeedNoPassphrase {
+			if currTest.Mnemonic == noPassTest.Mnemonic && currTest.Seed == noPassTest.Seed {
+				t.Errorf("Mnemonic '%s' seed with passphrase is equal to the one without passphrase", currTest.Mnemonic)
+			}
+		}
+	}
+}
+
+// Test seeds with different passphrases
+func TestSeedsDiffer(t *testing.T) {
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+
+		// Different passphrases, including empty and differently cased ones
+		for _, testPass := range [][2]string { { "", testPassphrase }, { testPassphrase, strings.ToLower(testPassphrase) }, { testPassphrase, testPassphrase + " " } } {
+			differ, err := mnemonic.SeedsDiffer(testPass[0], testPass[1])
+			if err != nil {
+				t.Errorf("Mnemonic '%s' seeds comparison returned error: %s", currTest.Mnemonic, err.Error())
+			} else if !differ {
+				t.Errorf("Mnemonic '%s' seeds with passphrases '%s' and '%s' did not differ", currTest.Mnemonic, testPass[0], testPass[1])
+			}
+		}
+		// Same passphrase, also in a different normal form
+		for _, testPass := range [][2]string { { testPassphrase, testPassphrase }, { "", "" }, { "caf\u00e9", "cafe\u0301" } } {
+			if differ, _ := mnemonic.SeedsDiffer(testPass[0], testPass[1]); differ {
+				t.Errorf("Mnemonic '%s' seeds with passphrases '%s' and '%s' differed", currTest.Mnemonic, testPass[0], testPass[1])
+			}
+		}
+	}
+
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if differ, err := MnemonicFromString(currTest.Mnemonic).SeedsDiffer("", testPassphrase); differ || !errors.Is(err, currTest.Err) {
+			t.Errorf("Invalid mnemonic '%s' seeds comparison returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
+
+// Test mnemonic matching entropy
+func TestMnemonicMatchesEntropy(t *testing.T) {
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+
+		// Same entropy
+		matches, err := mnemonic.MatchesEntropy(entropy)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' entropy matching returned error: %s", currTest.Mnemonic, err.Error())
+		} else if !matches {
+			t.Errorf("Mnemonic '%s' did not match entropy %s", currTest.Mnemonic, currTest.Entropy)
+		}
+
+		// Entropy with a different bit or length
+		entropyDiff := append([]byte {}, entropy...)
+		entropyDiff[len(entropyDiff) - 1] ^= 0x01
+		for _, testEntropy := range [][]byte { entropyDiff, entropy[:len(entropy) - 1], append(entropy, 0), nil } {
+			if matches, _ = mnemonic.MatchesEntropy(testEntropy); matches {
+				t.Errorf("Mnemonic '%s' matched wrong entropy %x", currTest.Mnemonic, testEntropy)
+			}
+		}
+	}
+
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if matches, err := MnemonicFromString(currTest.Mnemonic).MatchesEntropy(nil); matches || !errors.Is(err, currTest.Err) {
+			t.Errorf("Invalid mnemonic '%s' entropy matching returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
+
+// Test seed generation with custom salt prefix and rounds
+func TestSeedWithSalt(t *testing.T) {
+	// Default parameters shall match the test vectors
+	for _, currTest := range testVect {
+		seed, err := MnemonicFromString(currTest.Mnemonic).GenerateSeedWithSalt(testPassphrase, "mnemonic", 2048)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed generation with salt returned error: %s", currTest.Mnemonic, err.Error())
+		} else if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Mnemonic '%s' seed with salt was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Seed, seed)
+		}
+	}
+
+	// Custom parameters
+	for _, currTest := range testVectSeedWithSalt {
+		seed, err := MnemonicFromString(currTest.Mnemonic).GenerateSeedWithSalt(testPassphrase, currTest.SaltPrefix, currTest.Rounds)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed generation with salt '%s' returned error: %s", currTest.Mnemonic, currTest.SaltPrefix, err.Error())
+		} else if hex.EncodeToString(seed) != currTest.Seed {
+			t.Errorf("Mnemonic '%s' seed with salt '%s' was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.SaltPrefix, currTest.Seed, seed)
+		}
+	}
+
+	// Invalid rounds
+	mnemonic := MnemonicFromString(testVect[0].Mnemonic)
+	for _, rounds := range []int { 0, -1 } {
+		if _, err := mnemonic.GenerateSeedWithSalt(testPassphrase, "mnemonic", rounds); !errors.Is(err, ErrSeedRounds) {
+			t.Errorf("Seed generation with invalid rounds (%d) returned wrong error (%v)", rounds, err)
+		}
+	}
+
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if _, err := MnemonicFromString(currTest.Mnemonic).GenerateSeedWithSalt(testPassphrase, "mnemonic", 2048); !errors.Is(err, currTest.Err) {
+			t.Errorf("Seed with salt from invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
+
+// Test mnemonic fingerprint
+func TestFingerprint(t *testing.T) {
+	for _, testEntry := range testVectFingerprint {
+		fprint, err := MnemonicFromString(testEntry.Mnemonic).Fingerprint(testEntry.Passphrase)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' fingerprint returned error: %s", testEntry.Mnemonic, err.Error())
+		} else if hex.EncodeToString(fprint) != testEntry.Fingerprint {
+			t.Errorf("Mnemonic '%s' fingerprint was incorrect: expected %s, got: %x", testEntry.Mnemonic, testEntry.Fingerprint, fprint)
+		}
+	}
+
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if _, err := MnemonicFromString(currTest.Mnemonic).Fingerprint(testPassphrase); !errors.Is(err, currTest.Err) {
+			t.Errorf("Fingerprint of invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
+
+// Test seed verification
+func TestVerifySeed(t *testing.T) {
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+		seed, _ := hex.DecodeString(currTest.Seed)
+
+		// Correct seed
+		ok, err := mnemonic.VerifySeed(testPassphrase, seed)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' seed verification returned error: %s", currTest.Mnemonic, err.Error())
+		} else if !ok {
+			t.Errorf("Mnemonic '%s' seed verification failed", currTest.Mnemonic)
+		}
+
+		// Wrong passphrase
+		if ok, _ := mnemonic.VerifySeed("", seed); ok {
+			t.Errorf("Mnemonic '%s' seed verification with wrong passphrase succeeded", currTest.Mnemonic)
+		}
+		// Wrong seed
+		seed[0] ^= 0x01
+		if ok, _ := mnemonic.VerifySeed(testPassphrase, seed); ok {
+			t.Errorf("Mnemonic '%s' seed verification with wrong seed succeeded", currTest.Mnemonic)
+		}
+		// Truncated seed
+		if ok, _ := mnemonic.VerifySeed(testPassphrase, seed[:32]); ok {
+			t.Errorf("Mnemonic '%s' seed verification with truncated seed succeeded", currTest.Mnemonic)
+		}
+	}
+
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if ok, err := MnemonicFromString(currTest.Mnemonic).VerifySeed(testPassphrase, nil); ok || !errors.Is(err, currTest.Err) {
+			t.Errorf("Seed verification of invalid mnemonic (%s) returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+}
+
+// Test low quality mnemonics
+func TestIsLowQuality(t *testing.T) {
+	// Test vectors with all bytes equal shall be of low quality
+	for _, currTest := range testVect {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+		if isLowQuality := MnemonicFromString(currTest.Mnemonic).IsLowQuality(); isLowQuality != isWeakEntropy(entropy) {
+			t.Errorf("Mnemonic '%s' low quality was incorrect: expected %t, got: %t", currTest.Mnemonic, isWeakEntropy(entropy), isLowQuality)
+		}
+	}
+
+	for _, testBitLen := range testVectEntropyBitLenValid {
+		// All zeros and all ones
+		for _, b := range []byte { 0x00, 0xff } {
+			mnemonic, _ := MnemonicFromEntropy(bytes.Repeat([]byte { b }, testBitLen / 8))
+			if !mnemonic.IsLowQuality() {
+				t.Errorf("Mnemonic '%s' was not of low quality", mnemonic.Words)
+			}
+		}
+		// Mostly repeated words
+		entropy := make([]byte, testBitLen / 8)
+		entropy[len(entropy) - 1] = 0x01
+		mnemonic, _ := MnemonicFromEntropy(entropy)
+		if !mnemonic.IsLowQuality() {
+			t.Errorf("Mnemonic '%s' was not of low quality", mnemonic.Words)
+		}
+		// Random
+		mnemonic, _ = MnemonicFromDeterministicSeed("quality", testBitLen)
+		if mnemonic.IsLowQuality() {
+			t.Errorf("Mnemonic '%s' was of low quality", mnemonic.Words)
+		}
+	}
+
+	// Invalid mnemonics
+	for _, currTest := range testVectMnemonicInvalid {
+		if MnemonicFromString(currTest.Mnemonic).IsLowQuality() {
+			t.Errorf("Invalid mnemonic '%s' was of low quality", currTest.Mnemonic)
+		}
+	}
+}
+
+// Test mnemonic from checked entropy
+func TestEntropyChecked(t *testing.T) {
+	for _, currTest := range testVect {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+		mnemonic, err := MnemonicFromEntropyChecked(entropy)
+
+		// Test vectors with all bytes equal shall be rejected
+		if isWeakEntropy(entropy) {
+			if mnemonic != nil || !errors.Is(err, ErrWeakEntropy) {
+				t.Errorf("Mnemonic from weak entropy %s returned wrong error (%v)", currTest.Entropy, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Mnemonic from checked entropy %s returned error: %s", currTest.Entropy, err.Error())
+		} else if mnemonic.Words != currTest.Mnemonic {
+			t.Errorf("Mnemonic from checked entropy was incorrect: expected %s, got: %s", currTest.Mnemonic, mnemonic.Words)
+		}
+	}
+
+	// Weak entropy
+	for _, testBitLen := range testVectEntropyBitLenValid {
+		for _, b := range []byte { 0x00, 0xff, 0x7f } {
+			entropy := bytes.Repeat([]byte { b }, testBitLen / 8)
+			if _, err := MnemonicFromEntropyChecked(entropy); !errors.Is(err, ErrWeakEntropy) {
+				t.Errorf("Mnemonic from weak entropy %x returned wrong error (%v)", entropy, err)
+			}
+		}
+	}
+
+	// Invalid entropy bit length
+	if _, err := MnemonicFromEntropyChecked(nil); !errors.Is(err, ErrEntropyBitLen) {
+		t.Errorf("Mnemonic from empty checked entropy returned wrong error (%v)", err)
+	}
+}
+
+// Test checksum bit length
+func TestChecksumBitLen(t *testing.T) {
+	expChksumBitLen := []int { 4, 5, 6, 7, 8 }
+	for i, testBitLen := range testVectEntropyBitLenValid {
+		if chksumBitLen := ChecksumBitLen(testBitLen); chksumBitLen != expChksumBitLen[i] {
+			t.Errorf("Checksum bit length for %d-bit entropy was incorrect: expected %d, got: %d", testBitLen, expChksumBitLen[i], chksumBitLen)
+		}
+		// Entropy and checksum shall fill exactly the words
+		if wordsNum := entropyBitLenToWordsNum(testBitLen); (testBitLen + ChecksumBitLen(testBitLen)) != wordsNum * WordBitLen {
+			t.Errorf("Entropy and checksum bit length for %d-bit entropy is not a multiple of the word bit length", testBitLen)
+		}
+	}
+}
+
+// Test last word entropy bits
+func TestLastWordEntropyBits(t *testing.T) {
+	expEntropyBits := []int { 7, 6, 5, 4, 3 }
+	for i, testWordsNum := range testVectWordsNumValid {
+		if entropyBits := LastWordEntropyBits(testWordsNum); entropyBits != expEntropyBits[i] {
+			t.Errorf("Last word entropy bits for %d words was incorrect: expected %d, got: %d", testWordsNum, expEntropyBits[i], entropyBits)
+		}
+	}
+
+	// Invalid words number
+	for _, testWordsNum := range testVectWordsNumInvalid {
+		if entropyBits := LastWordEntropyBits(testWordsNum); entropyBits != 0 {
+			t.Errorf("Last word entropy bits for invalid words number (%d) was not zero: got: %d", testWordsNum, entropyBits)
+		}
+	}
+}
+
+// Test checksum word
+func TestChecksumWord(t *testing.T) {
+	for _, currTest := range testVect {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+		words := strings.Fields(currTest.Mnemonic)
+
+		chksumWord, err := ChecksumWord(entropy, LangEnglish)
+		if err != nil {
+			t.Errorf("Checksum word of entropy %s returned error: %s", currTest.Entropy, err.Error())
+		} else if chksumWord != words[len(words) - 1] {
+			t.Errorf("Checksum word of entropy %s was incorrect: expected %s, got: %s", currTest.Entropy, words[len(words) - 1], chksumWord)
+		}
+	}
+
+	// Other languages
+	for _, currTest := range testVectLang {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+		words := strings.Fields(currTest.Mnemonic)
+		if chksumWord, _ := ChecksumWord(entropy, currTest.Lang); chksumWord != words[len(words) - 1] {
+			t.Errorf("Checksum word of entropy %s was incorrect: expected %s, got: %s", currTest.Entropy, words[len(words) - 1], chksumWord)
+		}
+	}
+
+	// Invalid entropy length
+	for _, testByteLen := range []int { 0, 15, 17, 33 } {
+		if _, err := ChecksumWord(make([]byte, testByteLen), LangEnglish); !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Checksum word of entropy with invalid length (%d) returned wrong error (%v)", testByteLen, err)
+		}
+	}
+	// Unsupported language
+	if _, err := ChecksumWord(make([]byte, EntropyBits128 / 8), Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Checksum word with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test mnemonic from hex entropy
+func TestEntropyHex(t *testing.T) {
+	for _, currTest := range testVect {
+		for _, entropyHex := range []string { currTest.Entropy, strings.ToUpper(currTest.Entropy) } {
+			mnemonic, err := MnemonicFromEntropyHex(entropyHex, LangEnglish)
+			if err != nil {
+				t.Errorf("Mnemonic from hex entropy %s returned error: %s", entropyHex, err.Error())
+			} else if mnemonic.Words != currTest.Mnemonic {
+				t.Errorf("Mnemonic from hex entropy was incorrect: expected %s, got: %s", currTest.Mnemonic, mnemonic.Words)
+			}
+		}
+	}
+
+	// Odd length
+	if _, err := MnemonicFromEntropyHex(testVect[0].Entropy[1:], LangEnglish); !errors.Is(err, hex.ErrLength) {
+		t.Errorf("Mnemonic from odd-length hex entropy returned wrong error (%v)", err)
+	}
+	// Not hex characters
+	var errByte hex.InvalidByteError
+	if _, err := MnemonicFromEntropyHex("0x" + testVect[0].Entropy[2:], LangEnglish); !errors.As(err, &errByte) {
+		t.Errorf("Mnemonic from not hex entropy returned wrong error (%v)", err)
+	}
+	// Invalid entropy bit length
+	if _, err := MnemonicFromEntropyHex(testVect[0].Entropy[2:], LangEnglish); !errors.Is(err, ErrEntropyBitLen) {
+		t.Errorf("Mnemonic from invalid hex entropy returned wrong error (%v)", err)
+	}
+	// Unsupported language
+	if _, err := MnemonicFromEntropyHex(testVect[0].Entropy, Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Mnemonic from hex entropy with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test mnemonic key space bits
+func TestMnemonicSpaceBits(t *testing.T) {
+	for i, testWordsNum := range testVectWordsNumValid {
+		spaceBits, err := MnemonicSpaceBits(testWordsNum)
+		if err != nil {
+			t.Errorf("Key space bits for %d words returned error: %s", testWordsNum, err.Error())
+		} else if spaceBits != testVectEntropyBitLenValid[i] {
+			t.Errorf("Key space bits for %d words was incorrect: expected %d, got: %d", testWordsNum, testVectEntropyBitLenValid[i], spaceBits)
+		}
+		// Checksum bits shall not be counted
+		if spaceBits + ChecksumBitLen(spaceBits) != testWordsNum * WordBitLen {
+			t.Errorf("Key space bits for %d words counts checksum bits", testWordsNum)
+		}
+	}
+
+	// Invalid words number
+	for _, testWordsNum := range testVectWordsNumInvalid {
+		if _, err := MnemonicSpaceBits(testWordsNum); !errors.Is(err, ErrWordsNum) {
+			t.Errorf("Key space bits for invalid words number (%d) returned wrong error (%v)", testWordsNum, err)
+		}
+	}
+}
+
+// Test mnemonic from truncated entropy
+func TestEntropyTruncated(t *testing.T) {
+	for _, currTest := range testVect {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+		wordsNum := entropyBitLenToWordsNum(len(entropy) * 8)
+
+		// Extra bytes shall be ignored
+		longEntropy := append(append([]byte {}, entropy...), bytes.Repeat([]byte { 0xa5 }, 32 - len(entropy) + 1)...)
+		mnemonic, err := MnemonicFromEntropyTruncated(longEntropy, wordsNum, LangEnglish)
+		if err != nil {
+			t.Errorf("Mnemonic from truncated entropy %s returned error: %s", currTest.Entropy, err.Error())
+		} else if mnemonic.Words != currTest.Mnemonic {
+			t.Errorf("Mnemonic from truncated entropy was incorrect: expected %s, got: %s", currTest.Mnemonic, mnemonic.Words)
+		}
+
+		// Entropy too short
+		if _, err := MnemonicFromEntropyTruncated(entropy[1:], wordsNum, LangEnglish); !errors.Is(err, ErrEntropyTooShort) {
+			t.Errorf("Mnemonic from too short entropy %s returned wrong error (%v)", currTest.Entropy, err)
+		}
+	}
+
+	// Invalid words number
+	for _, testWordsNum := range testVectWordsNumInvalid {
+		if _, err := MnemonicFromEntropyTruncated(make([]byte, 32), testWordsNum, LangEnglish); !errors.Is(err, ErrWordsNum) {
+			t.Errorf("Mnemonic from truncated entropy with invalid words number (%d) returned wrong error (%v)", testWordsNum, err)
+		}
+	}
+
+	// Unsupported language
+	if _, err := MnemonicFromEntropyTruncated(make([]byte, 32), WordsNum12, Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Mnemonic from truncated entropy with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test mnemonic entropy extension
+func TestExtendEntropy(t *testing.T) {
+	for _, currTest := range testVectExtendEntropy {
+		mnemonic, err := MnemonicFromString(currTest.Mnemonic).ExtendEntropy(currTest.WordsNum, LangEnglish)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' entropy extension returned error: %s", currTest.Mnemonic, err.Error())
+		} else if mnemonic.Words != currTest.Extended {
+			t.Errorf("Mnemonic entropy extension was incorrect: expected %s, got: %s", currTest.Extended, mnemonic.Words)
+		}
+	}
+
+	// Target words number not greater than the current one
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+		for _, testWordsNum := range testVectWordsNumValid {
+			if testWordsNum > mnemonic.WordCount() {
+				continue
+			}
+			if _, err := mnemonic.ExtendEntropy(testWordsNum, LangEnglish); !errors.Is(err, ErrWordsNum) {
+				t.Errorf("Mnemonic '%s' entropy extension to %d words returned wrong error (%v)", currTest.Mnemonic, testWordsNum, err)
+			}
+		}
+	}
+	// Invalid target words number
+	for _, testWordsNum := range testVectWordsNumInvalid {
+		if _, err := MnemonicFromString(testVect[0].Mnemonic).ExtendEntropy(testWordsNum, LangEnglish); !errors.Is(err, ErrWordsNum) {
+			t.Errorf("Mnemonic entropy extension to invalid words number (%d) returned wrong error (%v)", testWordsNum, err)
+		}
+	}
+	// Invalid mnemonic
+	for _, currTest := range testVectMnemonicInvalid {
+		if _, err := MnemonicFromString(currTest.Mnemonic).ExtendEntropy(WordsNum24, LangEnglish); !errors.Is(err, currTest.Err) {
+			t.Errorf("Invalid mnemonic '%s' entropy extension returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+	// Unsupported language
+	if _, err := MnemonicFromString(testVect[0].Mnemonic).ExtendEntropy(WordsNum24, Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Mnemonic entropy extension with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test mnemonic from words number with first word
+func TestWordsNumWithFirstWord(t *testing.T) {
+	for _, testWordsNum := range testVectWordsNumValid {
+		for _, testLang := range SupportedLanguages() {
+			wordsList, _ := getWordsList(testLang)
+			firstWord := wordsList.words[testWordsNum * 50]
+
+			mnemonic, err := MnemonicWithFirstWord(firstWord, testWordsNum, testLang)
+			if err != nil {
+				t.Errorf("Mnemonic from words number %d with first word '%s' returned error: %s", testWordsNum, firstWord, err.Error())
+				continue
+			}
+			if mnemonic.WordCount() != testWordsNum || !mnemonic.IsValid() {
+				t.Errorf("Mnemonic '%s' from words number %d with first word is not valid", mnemonic.Words, testWordsNum)
+			}
+			if mnemonic.WordSlice()[0] != firstWord {
+				t.Errorf("Mnemonic '%s' doesn't start with word '%s'", mnemonic.Words, firstWord)
+			}
+		}
+	}
+
+	// Invalid parameters
+	if _, err := MnemonicWithFirstWord("legal", testVectWordsNumInvalid[0], LangEnglish); !errors.Is(err, ErrWordsNum) {
+		t.Errorf("Mnemonic from invalid words number with first word returned wrong error (%v)", err)
+	}
+	for _, testWord := range []string { "", "notexistent", "ábaco" } {
+		if _, err := MnemonicWithFirstWord(testWord, WordsNum12, LangEnglish); !errors.Is(err, ErrInvalidWord) {
+			t.Errorf("Mnemonic from words number with invalid first word '%s' returned wrong error (%v)", testWord, err)
+		}
+	}
+	// First word shall be normalized
+	for _, testWord := range [][2]string { { "LEGAL", "legal" }, { " Zoo\t", "zoo" } } {
+		mnemonic, err := MnemonicWithFirstWord(testWord[0], WordsNum12, LangEnglish)
+		if err != nil {
+			t.Errorf("Mnemonic from words number with first word %q returned error: %s", testWord[0], err.Error())
+		} else if mnemonic.WordSlice()[0] != testWord[1] || !mnemonic.IsValid() {
+			t.Errorf("Mnemonic '%s' doesn't start with word '%s'", mnemonic.Words, testWord[1])
+		}
+	}
+	mnemonic, err := MnemonicWithFirstWord("\u00c1baco", WordsNum12, LangSpanish)
+	if err != nil {
+		t.Errorf("Mnemonic from words number with composed first word returned error: %s", err.Error())
+	} else if mnemonic.WordSlice()[0] != "a\u0301baco" || !mnemonic.IsValid() {
+		t.Errorf("Mnemonic '%s' doesn't start with word 'ábaco'", mnemonic.Words)
+	}
+	if _, err := MnemonicWithFirstWord("legal", WordsNum12, Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Mnemonic from words number with first word and unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test generation of multiple mnemonics
+func TestGenerateMnemonics(t *testing.T) {
+	const count = 10
+
+	for _, testWordsNum := range testVectWordsNumValid {
+		mnemonics, err := GenerateMnemonics(count, testWordsNum, LangSpanish)
+		if err != nil {
+			t.Errorf("Generation of %d-word mnemonics returned error: %s", testWordsNum, err.Error())
+			continue
+		}
+		if len(mnemonics) != count {
+			t.Errorf("Number of generated mnemonics was incorrect: expected %d, got: %d", count, len(mnemonics))
+		}
+
+		mnemonicsMap := map[string]bool {}
+		for _, mnemonic := range mnemonics {
+			if mnemonic.WordCount() != testWordsNum {
+				t.Errorf("Generated mnemonic words number was incorrect: expected %d, got: %d", testWordsNum, mnemonic.WordCount())
+			}
+			if err := mnemonic.Validate(); err != nil {
+				t.Errorf("Generated mnemonic '%s' validation returned error: %s", mnemonic.Words, err.Error())
+			}
+			if lang, _ := DetectLanguage(mnemonic.Words); lang != LangSpanish {
+				t.Errorf("Generated mnemonic '%s' language was incorrect: expected %s, got: %s", mnemonic.Words, LangSpanish, lang)
+			}
+			// Mnemonics shall be independent
+			if mnemonicsMap[mnemonic.Words] {
+				t.Errorf("Generated mnemonic '%s' is duplicated", mnemonic.Words)
+			}
+			mnemonicsMap[mnemonic.Words] = true
+		}
+	}
+
+	// Invalid count
+	for _, testCount := range []int { 0, -1 } {
+		if _, err := GenerateMnemonics(testCount, WordsNum12, LangEnglish); !errors.Is(err, ErrMnemonicsCount) {
+			t.Errorf("Generation of mnemonics with invalid count (%d) returned wrong error (%v)", testCount, err)
+		}
+	}
+	// Invalid words number
+	for _, testWordsNum := range testVectWordsNumInvalid {
+		if _, err := GenerateMnemonics(count, testWordsNum, LangEnglish); !errors.Is(err, ErrWordsNum) {
+			t.Errorf("Generation of mnemonics with invalid words number (%d) returned wrong error (%v)", testWordsNum, err)
+		}
+	}
+	// Unsupported language
+	if _, err := GenerateMnemonics(count, WordsNum12, Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Generation of mnemonics with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test mnemonic from words number with prefix
+func TestWordsNumWithPrefix(t *testing.T) {
+	for _, testWordsNum := range testVectWordsNumValid {
+		// Test all prefix lengths
+		prefixWords := strings.Split(testVect[len(testVect) - 1].Mnemonic, " ")[:testWordsNum - 1]
+		for i := 0; i < testWordsNum; i++ {
+			mnemonic, err := MnemonicFromWordsNumWithPrefix(testWordsNum, prefixWords[:i], LangEnglish)
+			if err != nil {
+				t.Errorf("Mnemonic from words number %d with prefix %v returned error: %s", testWordsNum, prefixWords[:i], err.Error())
+				continue
+			}
+			if mnemonic.WordCount() != testWordsNum || !mnemonic.IsValid() {
+				t.Errorf("Mnemonic '%s' from words number %d with prefix is not valid", mnemonic.Words, testWordsNum)
+			}
+			if strings.Join(mnemonic.WordSlice()[:i], " ") != strings.Join(prefixWords[:i], " ") {
+				t.Errorf("Mnemonic '%s' doesn't start with prefix %v", mnemonic.Words, prefixWords[:i])
+			}
+		}
+
+		// Prefix too long
+		longPrefix := strings.Split(testVect[len(testVect) - 1].Mnemonic, " ")[:testWordsNum]
+		if _, err := MnemonicFromWordsNumWithPrefix(testWordsNum, longPrefix, LangEnglish); !errors.Is(err, ErrWordsNum) {
+			t.Errorf("Mnemonic from words number %d with too long prefix returned wrong error (%v)", testWordsNum, err)
+		}
+	}
+
+	// Invalid parameters
+	if _, err := MnemonicFromWordsNumWithPrefix(testVectWordsNumInvalid[0], nil, LangEnglish); !errors.Is(err, ErrWordsNum) {
+		t.Errorf("Mnemonic from invalid words number with prefix returned wrong error (%v)", err)
+	}
+	if _, err := MnemonicFromWordsNumWithPrefix(WordsNum12, []string { "legal", "notexistent" }, LangEnglish); !errors.Is(err, ErrInvalidWord) {
+		t.Errorf("Mnemonic from words number with invalid prefix returned wrong error (%v)", err)
+	}
+	// Prefix words shall be normalized
+	mnemonic, err := MnemonicFromWordsNumWithPrefix(WordsNum12, []string { "ZOO", " Legal ", "Winner" }, LangEnglish)
+	if err != nil {
+		t.Errorf("Mnemonic from words number with prefix to be normalized returned error: %s", err.Error())
+	} else if strings.Join(mnemonic.WordSlice()[:3], " ") != "zoo legal winner" || !mnemonic.IsValid() {
+		t.Errorf("Mnemonic '%s' doesn't start with normalized prefix", mnemonic.Words)
+	}
+	if _, err := MnemonicFromWordsNumWithPrefix(WordsNum12, []string { "legal" }, Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Mnemonic from words number with prefix and unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test empty and whitespace-only mnemonics
+func TestMnemonicEmpty(t *testing.T) {
+	for _, testStr := range []string { "", " ", "   ", "\t\n" } {
+		for _, mnemonic := range []*Mnemonic { MnemonicFromString(testStr), MnemonicFromStringRaw(testStr) } {
+			if mnemonic.WordCount() != 0 {
+				t.Errorf("Mnemonic %q words count was incorrect: expected 0, got: %d", testStr, mnemonic.WordCount())
+			}
+			if err := mnemonic.Validate(); !errors.Is(err, ErrWordsNum) {
+				t.Errorf("Mnemonic %q validation returned wrong error (%v)", testStr, err)
+			}
+		}
+	}
+
+	// Trailing spaces shall not affect a valid mnemonic
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromStringRaw(currTest.Mnemonic + " ")
+		if mnemonic.WordCount() != len(strings.Split(currTest.Mnemonic, " ")) {
+			t.Errorf("Mnemonic %q words count was incorrect", mnemonic.Words)
+		}
+		if err := mnemonic.Validate(); err != nil {
+			t.Errorf("Mnemonic %q with trailing space validation returned error: %s", mnemonic.Words, err.Error())
+		}
+		if seedHex, _ := mnemonic.GenerateSeedHex(testPassphrase); seedHex != currTest.Seed {
+			t.Errorf("Mnemonic %q with trailing space seed was incorrect: expected %s, got: %s", mnemonic.Words, currTest.Seed, seedHex)
+		}
+	}
+}
+
+// Test mnemonic to entropy into buffer
+func TestToEntropyInto(t *testing.T) {
+	buff := make([]byte, EntropyBits256 / 8)
+
+	for _, currTest := range testVect {
+		n, err := MnemonicFromString(currTest.Mnemonic).ToEntropyInto(buff)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' to entropy into buffer returned error: %s", currTest.Mnemonic, err.Error())
+		} else if hex.EncodeToString(buff[:n]) != currTest.Entropy {
+			t.Errorf("Mnemonic '%s' to entropy into buffer was incorrect: expected %s, got: %x", currTest.Mnemonic, currTest.Entropy, buff[:n])
+		}
+	}
+
+	// Invalid mnemonics
+	for _, currTest := range testVectMnemonicInvalid {
+		n, err := MnemonicFromString(currTest.Mnemonic).ToEntropyInto(buff)
+		if n != 0 || !errors.Is(err, currTest.Err) {
+			t.Errorf("Invalid mnemonic (%s) to entropy into buffer returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+
+	// Buffer too short
+	_, err := MnemonicFromString(testVect[len(testVect) - 1].Mnemonic).ToEntropyInto(buff[:EntropyBits128 / 8])
+	if !errors.Is(err, io.ErrShortBuffer) {
+		t.Errorf("Mnemonic to entropy into short buffer returned wrong error (%v)", err)
+	}
+}
+
+// Test words validation
+func TestValidateWords(t *testing.T) {
+	for _, currTest := range testVect {
+		if err := MnemonicFromString(currTest.Mnemonic).ValidateWords(LangEnglish); err != nil {
+			t.Errorf("Mnemonic '%s' words validation returned error: %s", currTest.Mnemonic, err.Error())
+		}
+	}
+	for _, currTest := range testVectLang {
+		if err := MnemonicFromStringLang(currTest.Mnemonic, currTest.Lang).ValidateWords(currTest.Lang); err != nil {
+			t.Errorf("Mnemonic '%s' words validation returned error: %s", currTest.Mnemonic, err.Error())
+		}
+	}
+
+	// Invalid mnemonics, the checksum shall not be verified
+	for _, currTest := range testVectMnemonicInvalid {
+		err := MnemonicFromString(currTest.Mnemonic).ValidateWords(LangEnglish)
+		if currTest.Err == ErrChecksum {
+			if err != nil {
+				t.Errorf("Mnemonic '%s' with invalid checksum words validation returned error: %s", currTest.Mnemonic, err.Error())
+			}
+		} else if !errors.Is(err, currTest.Err) {
+			t.Errorf("Invalid mnemonic '%s' words validation returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+	// Word position
+	var wordErr *WordError
+	err := MnemonicFromString("legal winner thank year wave sausage worth useful legal winner thank yelow").ValidateWords(LangEnglish)
+	if !errors.As(err, &wordErr) || wordErr.Index != 11 {
+		t.Errorf("Mnemonic with invalid word words validation returned wrong error (%v)", err)
+	}
+	// Words of another language
+	if err := MnemonicFromString(testVect[0].Mnemonic).ValidateWords(LangFrench); !errors.Is(err, ErrInvalidWord) {
+		t.Errorf("Mnemonic words validation with another language returned wrong error (%v)", err)
+	}
+	// Unsupported language
+	if err := MnemonicFromString(testVect[0].Mnemonic).ValidateWords(Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Mnemonic words validation with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test validation with expected words number
+func TestValidateExpectLen(t *testing.T) {
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+		wordsNum := mnemonic.WordCount()
+
+		if err := mnemonic.ValidateExpectLen(wordsNum); err != nil {
+			t.Errorf("Mnemonic '%s' validation with expected words number returned error: %s", currTest.Mnemonic, err.Error())
+		}
+		// Other valid words numbers shall be rejected
+		for _, testWordsNum := range testVectWordsNumValid {
+			if testWordsNum == wordsNum {
+				continue
+			}
+			if err := mnemonic.ValidateExpectLen(testWordsNum); !errors.Is(err, ErrUnexpectedWordsNum) {
+				t.Errorf("Mnemonic '%s' validation with unexpected words number (%d) returned wrong error (%v)", currTest.Mnemonic, testWordsNum, err)
+			}
+		}
+	}
+
+	// Words number shall be checked before the checksum
+	if err := MnemonicFromString("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon").ValidateExpectLen(WordsNum24); !errors.Is(err, ErrUnexpectedWordsNum) {
+		t.Errorf("Mnemonic with invalid checksum validation with unexpected words number returned wrong error (%v)", err)
+	}
+	// Invalid mnemonic with expected words number
+	for _, currTest := range testVectMnemonicInvalid {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+		if validateWordsNum(mnemonic.WordCount()) != nil {
+			continue
+		}
+		if err := mnemonic.ValidateExpectLen(mnemonic.WordCount()); !errors.Is(err, currTest.Err) {
+			t.Errorf("Invalid mnemonic '%s' validation with expected words number returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+	// Invalid expected words number
+	for _, testWordsNum := range testVectWordsNumInvalid {
+		if err := MnemonicFromString(testVect[0].Mnemonic).ValidateExpectLen(testWordsNum); !errors.Is(err, ErrWordsNum) {
+			t.Errorf("Validation with invalid expected words number (%d) returned wrong error (%v)", testWordsNum, err)
+		}
+	}
+}
+
+// Test strict validation
+func TestValidateStrict(t *testing.T) {
+	for _, currTest := range testVect {
+		if err := MnemonicFromStringRaw(currTest.Mnemonic).ValidateStrict(LangEnglish); err != nil {
+			t.Errorf("Mnemonic '%s' strict validation returned error: %s", currTest.Mnemonic, err.Error())
+		}
+	}
+
+	// Non-canonical mnemonics, which are valid in lenient mode
+	for _, currTest := range testVectMnemonicNormalize {
+		mnemonic := MnemonicFromStringRaw(currTest.Mnemonic)
+		if currTest.Mnemonic == currTest.Normalized || !MnemonicFromString(currTest.Mnemonic).IsValid() {
+			continue
+		}
+		if err := mnemonic.ValidateStrict(LangEnglish); !errors.Is(err, ErrNonCanonicalForm) {
+			t.Errorf("Mnemonic %q strict validation returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+	for _, testStr := range []string {
+		"Abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about ",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon\tabout",
+	} {
+		if err := MnemonicFromStringRaw(testStr).ValidateStrict(LangEnglish); !errors.Is(err, ErrNonCanonicalForm) {
+			t.Errorf("Mnemonic %q strict validation returned wrong error (%v)", testStr, err)
+		}
+	}
+	// Composed (NFC) form of a non-ASCII mnemonic
+	for _, currTest := range testVectLang {
+		nfcMnemonic := norm.NFC.String(currTest.Mnemonic)
+		if nfcMnemonic == currTest.Mnemonic {
+			continue
+		}
+		if err := MnemonicFromStringRaw(nfcMnemonic).ValidateStrict(currTest.Lang); !errors.Is(err, ErrNonCanonicalForm) {
+			t.Errorf("Mnemonic '%s' (NFC) strict validation returned wrong error (%v)", nfcMnemonic, err)
+		}
+	}
+
+	// Invalid mnemonics
+	for _, currTest := range testVectMnemonicInvalid {
+		if err := MnemonicFromStringRaw(currTest.Mnemonic).ValidateStrict(LangEnglish); !errors.Is(err, currTest.Err) {
+			t.Errorf("Invalid mnemonic (%s) strict validation returned wrong error (%v)", currTest.Mnemonic, err)
+		}
+	}
+
+	// Normalizing constructors shall not hide the original form
+	for _, testStr := range []string {
+		"ABANDON ABANDON ABANDON ABANDON ABANDON ABANDON ABANDON ABANDON ABANDON ABANDON ABANDON ABOUT",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon  abandon about",
+		" abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	} {
+		if err := MnemonicFromString(testStr).ValidateStrict(LangEnglish); !errors.Is(err, ErrNonCanonicalForm) {
+			t.Errorf("Mnemonic %q from string strict validation returned wrong error (%v)", testStr, err)
+		}
+		if err := MnemonicFromStringLang(testStr, LangEnglish).ValidateStrict(LangEnglish); !errors.Is(err, ErrNonCanonicalForm) {
+			t.Errorf("Mnemonic %q from string with language strict validation returned wrong error (%v)", testStr, err)
+		}
+		// Lenient validation shall still accept it
+		if err := MnemonicFromString(testStr).Validate(); err != nil {
+			t.Errorf("Mnemonic %q from string validation returned error: %s", testStr, err.Error())
+		}
+	}
+	// Canonical mnemonics from normalizing constructors shall be valid
+	for _, currTest := range testVectLang {
+		if err := MnemonicFromStringLang(currTest.Mnemonic, currTest.Lang).ValidateStrict(currTest.Lang); err != nil {
+			t.Errorf("Mnemonic '%s' from string strict validation returned error: %s", currTest.Mnemonic, err.Error())
+		}
+	}
+	// Words modified after construction shall be checked instead of the original string
+	mnemonic := MnemonicFromString(strings.ToUpper(testVect[0].Mnemonic))
+	mnemonic.Words = testVect[1].Mnemonic
+	if err := mnemonic.ValidateStrict(LangEnglish); err != nil {
+		t.Errorf("Modified mnemonic '%s' strict validation returned error: %s", mnemonic.Words, err.Error())
+	}
+
+	if err := MnemonicFromStringRaw(testVect[0].Mnemonic).ValidateStrict(Language(-1)); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Strict validation with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Test batch validation
+func TestValidateBatch(t *testing.T) {
+	// Put valid and invalid mnemonics together
+	var mnemonics []string
+	var expErrs []error
+	for _, currTest := range testVect {
+		mnemonics = append(mnemonics, currTest.Mnemonic)
+		expErrs = append(expErrs, nil)
+	}
+	for _, currTest := range testVectMnemonicInvalid {
+		mnemonics = append(mnemonics, currTest.Mnemonic)
+		expErrs = append(expErrs, currTest.Err)
+	}
+
+	errs := ValidateBatch(mnemonics, LangEnglish)
+	if len(errs) != len(mnemonics) {
+		t.Fatalf("Batch validation errors number was incorrect: expected %d, got: %d", len(mnemonics), len(errs))
+	}
+	for i, err := range errs {
+		if expErrs[i] == nil && err != nil {
+			t.Errorf("Batch validation of mnemonic '%s' returned error: %s", mnemonics[i], err.Error())
+		} else if expErrs[i] != nil && !errors.Is(err, expErrs[i]) {
+			t.Errorf("Batch validation of mnemonic '%s' returned wrong error (%v)", mnemonics[i], err)
+		}
+	}
+
+	// Unsupported language
+	errs = ValidateBatch(mnemonics[:2], Language(-1))
+	for _, err := range errs {
+		if !errors.Is(err, ErrUnsupportedLanguage) {
+			t.Errorf("Batch validation with unsupported language returned wrong error (%v)", err)
+		}
+	}
+}
+
+// Test conversion between words number and entropy bit length
+func TestWordsNumEntropyBitLenConversion(t *testing.T) {
+	// Valid values (same index in both vectors)
+	for i, testWordsNum := range testVectWordsNumValid {
+		testBitLen := testVectEntropyBitLenValid[i]
+
+		bitLen, err := EntropyBitLenFromWordsNum(testWordsNum)
+		if err != nil {
+			t.Errorf("Entropy bit length from words number (%d) returned error: %s", testWordsNum, err.Error())
+		} else if bitLen != testBitLen {
+			t.Errorf("Entropy bit length from words number was incorrect: expected %d, got: %d", testBitLen, bitLen)
+		}
+
+		wordsNum, err := WordsNumFromEntropyBitLen(testBitLen)
+		if err != nil {
+			t.Errorf("Words number from entropy bit length (%d) returned error: %s", testBitLen, err.Error())
+		} else if wordsNum != testWordsNum {
+			t.Errorf("Words number from entropy bit length was incorrect: expected %d, got: %d", testWordsNum, wordsNum)
+		}
+
+		totalBits := testBitLen + ChecksumBitLen(testBitLen)
+		wordsNum, err = WordsNumFromBits(totalBits)
+		if err != nil {
+			t.Errorf("Words number from total bit length (%d) returned error: %s", totalBits, err.Error())
+		} else if wordsNum != testWordsNum {
+			t.Errorf("Words number from total bit length was incorrect: expected %d, got: %d", testWordsNum, wordsNum)
+		}
+	}
+	for i, totalBits := range []int { 132, 165, 198, 231, 264 } {
+		if wordsNum, _ := WordsNumFromBits(totalBits); wordsNum != testVectWordsNumValid[i] {
+			t.Errorf("Words number from total bit length (%d) was incorrect: expected %d, got: %d", totalBits, testVectWordsNumValid[i], wordsNum)
+		}
+	}
+
+	// Invalid values
+	for _, testWordsNum := range testVectWordsNumInvalid {
+		if _, err := EntropyBitLenFromWordsNum(testWordsNum); !errors.Is(err, ErrWordsNum) {
+			t.Errorf("Entropy bit length from invalid words number (%d) returned wrong error (%v)", testWordsNum, err)
+		}
+	}
+	for _, testBitLen := range testVectEntropyBitLenInvalid {
+		if _, err := WordsNumFromEntropyBitLen(testBitLen); !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Words number from invalid entropy bit length (%d) returned wrong error (%v)", testBitLen, err)
+		}
+	}
+	for _, testWordsNum := range testVectWordsNumInvalid {
+		if _, err := WordsNumFromBits(testWordsNum * WordBitLen); !errors.Is(err, ErrWordsNum) {
+			t.Errorf("Words number from invalid total bit length (%d) returned wrong error (%v)", testWordsNum * WordBitLen, err)
+		}
+	}
+	for _, totalBits := range []int { 0, -11, 128, 131, 133, 256, 275 } {
+		if _, err := WordsNumFromBits(totalBits); !errors.Is(err, ErrWordsNum) {
+			t.Errorf("Words number from invalid total bit length (%d) returned wrong error (%v)", totalBits, err)
+		}
+	}
+}
+
+// Test entropy type
+func TestEntropyType(t *testing.T) {
+	for _, testBitLen := range testVectEntropyBitLenValid {
+		entropy, _ := GenerateEntropy(testBitLen)
+		if entropy.BitLen() != testBitLen {
+			t.Errorf("Entropy bit length was incorrect: expected %d, got: %d", testBitLen, entropy.BitLen())
+		}
+		if !entropy.IsValid() {
+			t.Errorf("Entropy of valid bit length (%d) is not valid", testBitLen)
+		}
+
+		// It shall be usable as byte slice
+		if _, err := MnemonicFromEntropy(entropy); err != nil {
+			t.Errorf("Mnemonic from entropy type returned error: %s", err.Error())
+		}
+
+		entropy.Wipe()
+		if !bytes.Equal(entropy, make([]byte, len(entropy))) {
+			t.Errorf("Entropy was not wiped: %x", []byte(entropy))
+		}
+	}
+
+	for _, testBitLen := range testVectEntropyBitLenInvalid {
+		entropy := make(Entropy, (testBitLen - 8) / 8)
+		if entropy.IsValid() {
+			t.Errorf("Entropy of invalid bit length (%d) is valid", entropy.BitLen())
+		}
+	}
+}
+
+// Test entropy from dice rolls
+func TestDiceRolls(t *testing.T) {
+	for _, currTest := range testVectDice {
+		entropy, err := EntropyFromDiceRolls(currTest.Rolls, currTest.BitLen)
+		if err != nil {
+			t.Errorf("Entropy from dice rolls %s returned error: %s", currTest.Rolls, err.Error())
+		} else if hex.EncodeToString(entropy) != currTest.Entropy {
+			t.Errorf("Entropy from dice rolls was incorrect: expected %s, got: %x", currTest.Entropy, entropy)
+		}
+	}
+
+	// Round-trip for all bit lengths
+	testRollsNum := []int { 75, 87, 100, 112, 124 }
+	for i, testBitLen := range testVectEntropyBitLenValid {
+		if DiceRollsNum(testBitLen) != testRollsNum[i] {
+			t.Errorf("Dice rolls number for bit length %d was incorrect: expected %d, got: %d", testBitLen, testRollsNum[i], DiceRollsNum(testBitLen))
+		}
+
+		entropy, _ := GenerateEntropy(testBitLen)
+		rolls, err := DiceRollsFromEntropy(entropy)
+		if err != nil {
+			t.Errorf("Dice rolls from entropy %x returned error: %s", entropy, err.Error())
+		}
+		if len(rolls) != testRollsNum[i] {
+			t.Errorf("Dice rolls length was incorrect: expected %d, got: %d", testRollsNum[i], len(rolls))
+		}
+		gotEntropy, err := EntropyFromDiceRolls(rolls, testBitLen)
+		if err != nil {
+			t.Errorf("Entropy from dice rolls %s returned error: %s", rolls, err.Error())
+		} else if !bytes.Equal(gotEntropy, entropy) {
+			t.Errorf("Dice rolls round-trip was incorrect: expected %x, got: %x", entropy, gotEntropy)
+		}
+	}
+
+	// Invalid rolls
+	validRolls := testVectDice[0].Rolls
+	if _, err := EntropyFromDiceRolls(validRolls[1:], EntropyBits128); !errors.Is(err, ErrNotEnoughDiceRolls) {
+		t.Errorf("Entropy from not enough dice rolls returned wrong error (%v)", err)
+	}
+	for _, invalidRoll := range []string { "0", "7", "a", "-", "\u00e9" } {
+		if _, err := EntropyFromDiceRolls(validRolls + invalidRoll, EntropyBits128); !errors.Is(err, ErrDiceRolls) {
+			t.Errorf("Entropy from invalid dice roll '%s' returned wrong error (%v)", invalidRoll, err)
+		}
+	}
+	// Index of the invalid roll shall exclude whitespaces
+	if _, err := EntropyFromDiceRolls("123 4\u30005 67", EntropyBits128); !errors.Is(err, ErrDiceRolls) || !strings.Contains(err.Error(), "index 6") {
+		t.Errorf("Entropy from invalid dice roll returned wrong error (%v)", err)
+	}
+	// Minimum rolls shall carry enough bits for a negligible bias
+	for _, testBitLen := range testVectEntropyBitLenValid {
+		if rollsBits := float64(DiceRollsNum(testBitLen)) * math.Log2(6); rollsBits < float64(testBitLen + 64) {
+			t.Errorf("Dice rolls number for bit length %d carried too few bits: %f", testBitLen, rollsBits)
+		}
+	}
+	if _, err := EntropyFromDiceRolls(validRolls, testVectEntropyBitLenInvalid[0]); !errors.Is(err, ErrEntropyBitLen) {
+		t.Errorf("Entropy from dice rolls with invalid bit length returned wrong error (%v)", err)
+	}
+	// Invalid entropy lengths (empty and odd)
+	for _, testEntropy := range [][]byte { {}, make([]byte, 17) } {
+		if _, err := DiceRollsFromEntropy(testEntropy); !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Dice rolls from entropy with invalid length %d returned wrong error (%v)", len(testEntropy), err)
+		}
+	}
+}
+
+// Test entropy from coin flips
+func TestCoinFlips(t *testing.T) {
+	for _, currTest := range testVect {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+		flips := bytesToBinaryString(entropy)
+
+		gotEntropy, err := EntropyFromCoinFlips(flips, len(entropy) * 8)
+		if err != nil {
+			t.Errorf("Entropy from coin flips %s returned error: %s", flips, err.Error())
+		} else if !bytes.Equal(gotEntropy, entropy) {
+			t.Errorf("Entropy from coin flips was incorrect: expected %s, got: %x", currTest.Entropy, gotEntropy)
+		}
+	}
+
+	// Invalid flips
+	validFlips := strings.Repeat("01", EntropyBits128 / 2)
+	invalidFlips := []string {
+		validFlips[1:],
+		validFlips + "0",
+		validFlips[:EntropyBits128 - 8] + "+1010101",
+		validFlips[:EntropyBits128 - 1] + "2",
+		validFlips[:EntropyBits128 - 1] + "a",
+	}
+	for _, flips := range invalidFlips {
+		if _, err := EntropyFromCoinFlips(flips, EntropyBits128); !errors.Is(err, ErrBinaryString) {
+			t.Errorf("Entropy from invalid coin flips %s returned wrong error (%v)", flips, err)
+		}
+	}
+	if _, err := EntropyFromCoinFlips(validFlips, testVectEntropyBitLenInvalid[0]); !errors.Is(err, ErrEntropyBitLen) {
+		t.Errorf("Entropy from coin flips with invalid bit length returned wrong error (%v)", err)
+	}
+}
+
+// Test entropy generation with strength
+func TestEntropyStrength(t *testing.T) {
+	for _, testStrength := range []Strength { StrengthStandard, StrengthMedium, StrengthHigh } {
+		entropy, err := GenerateEntropyStrength(testStrength)
+		if err != nil {
+			t.Errorf("Entropy from strength (%d) returned error: %s", testStrength, err.Error())
+		} else if entropy.BitLen() != int(testStrength) {
+			t.Errorf("Entropy from strength was incorrect: expected %d, got: %d", testStrength, entropy.BitLen())
+		}
+	}
+
+	// Strengths shall match the words number
+	if entropyBitLenToWordsNum(int(StrengthStandard)) != WordsNum12 ||
+	   entropyBitLenToWordsNum(int(StrengthMedium)) != WordsNum18 ||
+	   entropyBitLenToWordsNum(int(StrengthHigh)) != WordsNum24 {
+		t.Errorf("Entropy strengths don't match the words number")
+	}
+
+	// Invalid strength
+	for _, testBitLen := range testVectEntropyBitLenInvalid {
+		if _, err := GenerateEntropyStrength(Strength(testBitLen)); !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Entropy from invalid strength (%d) returned wrong error (%v)", testBitLen, err)
+		}
+	}
+}
+
+// Test entropy generation with context
+func TestEntropyContext(t *testing.T) {
+	for _, testBitLen := range testVectEntropyBitLenValid {
+		entropy, err := GenerateEntropyContext(context.Background(), testBitLen)
+		if err != nil {
+			t.Errorf("Entropy from valid bit length (%d) with context returned error: %s", testBitLen, err.Error())
+		} else if len(entropy) * 8 != testBitLen {
+			t.Errorf("Entropy from valid bit length with context was incorrect: expected %d, got: %d", testBitLen, len(entropy) * 8)
+		}
+	}
+
+	// Invalid bit length
+	if _, err := GenerateEntropyContext(context.Background(), testVectEntropyBitLenInvalid[0]); !errors.Is(err, ErrEntropyBitLen) {
+		t.Errorf("Entropy from invalid bit length with context returned wrong error (%v)", err)
+	}
+
+	// Done context
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	entropy, err := GenerateEntropyContext(ctx, EntropyBits128)
+	if entropy != nil {
+		t.Errorf("Entropy with done context was not nil")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Entropy with done context returned wrong error (%v)", err)
+	}
+}
+
+// Test source of random bytes
+func TestSetRandReader(t *testing.T) {
+	defer SetRandReader(nil)
+
+	// Zero entropy shall give the first test vector
+	SetRandReader(bytes.NewReader(make([]byte, EntropyBits128 / 8)))
+	mnemonic, err := MnemonicFromWordsNum(WordsNum12)
+	if err != nil {
+		t.Errorf("Mnemonic from words number with custom reader returned error: %s", err.Error())
+	} else if mnemonic.Words != testVect[0].Mnemonic {
+		t.Errorf("Mnemonic from words number with custom reader was incorrect: expected %s, got: %s", testVect[0].Mnemonic, mnemonic.Words)
+	}
+
+	// Same source, same entropy
+	for _, currTest := range testVect {
+		expEntropy, _ := hex.DecodeString(currTest.Entropy)
+		SetRandReader(bytes.NewReader(expEntropy))
+		entropy, err := GenerateEntropy(len(expEntropy) * 8)
+		if err != nil {
+			t.Errorf("Entropy with custom reader returned error: %s", err.Error())
+		} else if !bytes.Equal(entropy, expEntropy) {
+			t.Errorf("Entropy with custom reader was incorrect: expected %s, got: %x", currTest.Entropy, entropy)
+		}
+	}
+
+	// Source returning one byte per call shall be read fully
+	SetRandReader(iotest.OneByteReader(bytes.NewReader(make([]byte, EntropyBits256 / 8))))
+	if entropy, err := GenerateEntropy(EntropyBits256); err != nil || !bytes.Equal(entropy, make([]byte, EntropyBits256 / 8)) {
+		t.Errorf("Entropy with one byte reader was incorrect: got: %x (%v)", entropy, err)
+	}
+
+	// Not enough random bytes
+	SetRandReader(bytes.NewReader(make([]byte, EntropyBits128 / 8 - 1)))
+	if _, err := MnemonicFromWordsNum(WordsNum12); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Mnemonic from words number with short reader returned wrong error (%v)", err)
+	}
+	SetRandReader(bytes.NewReader(nil))
+	if _, err := GenerateMnemonics(2, WordsNum12, LangEnglish); !errors.Is(err, io.EOF) {
+		t.Errorf("Mnemonics generation with empty reader returned wrong error (%v)", err)
+	}
+
+	// Default source shall be restored
+	SetRandReader(nil)
+	if randReader != rand.Reader {
+		t.Errorf("Default random reader was not restored")
+	}
+	if _, err := GenerateEntropy(EntropyBits128); err != nil {
+		t.Errorf("Entropy with default reader returned error: %s", err.Error())
+	}
+}
+
+// Test entropy generation from reader
+func TestEntropyFromReaderStrict(t *testing.T) {
+	for _, currTest := range testVect {
+		expEntropy, _ := hex.DecodeString(currTest.Entropy)
+
+		// Reader returning one byte per call
+		entropy, err := GenerateEntropyFromReaderStrict(iotest.OneByteReader(bytes.NewReader(expEntropy)), len(expEntropy) * 8)
+		if err != nil {
+			t.Errorf("Entropy from one byte reader returned error: %s", err.Error())
+		} else if !bytes.Equal(entropy, expEntropy) {
+			t.Errorf("Entropy from one byte reader was incorrect: expected %s, got: %x", currTest.Entropy, entropy)
+		}
+		// Reader with extra bytes
+		entropy, err = GenerateEntropyFromReaderStrict(bytes.NewReader(append(append([]byte {}, expEntropy...), 0xff)), len(expEntropy) * 8)
+		if err != nil || !bytes.Equal(entropy, expEntropy) {
+			t.Errorf("Entropy from long reader was incorrect: expected %s, got: %x (%v)", currTest.Entropy, entropy, err)
+		}
+		// Short reader
+		entropy, err = GenerateEntropyFromReaderStrict(iotest.OneByteReader(bytes.NewReader(expEntropy[1:])), len(expEntropy) * 8)
+		if entropy != nil || !errors.Is(err, io.ErrUnexpectedEOF) {
+			t.Errorf("Entropy from short reader returned wrong error (%v)", err)
+		}
+	}
+
+	// Empty reader
+	if _, err := GenerateEntropyFromReaderStrict(bytes.NewReader(nil), EntropyBits128); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("Entropy from empty reader returned wrong error (%v)", err)
+	}
+	// Reader error
+	if _, err := GenerateEntropyFromReaderStrict(iotest.TimeoutReader(iotest.OneByteReader(bytes.NewReader(make([]byte, 32)))), EntropyBits128); !errors.Is(err, iotest.ErrTimeout) {
+		t.Errorf("Entropy from failing reader returned wrong error (%v)", err)
+	}
+	// Invalid bit length
+	for _, testBitLen := range testVectEntropyBitLenInvalid {
+		if _, err := GenerateEntropyFromReaderStrict(bytes.NewReader(make([]byte, 64)), testBitLen); !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Entropy from reader with invalid bit length (%d) returned wrong error (%v)", testBitLen, err)
+		}
+	}
+}
+
+// Test entropy from hashed reader
+func TestEntropyFromReaderHashed(t *testing.T) {
+	defer SetRandReader(nil)
+
+	// SHA-512 of "abc"
+	expHash, _ := hex.DecodeString("ddaf35a193617abacc417349ae20413112e6fa4e89a97ea20a9eeee64b55d39a2192992a274fc1a836ba3c23a3feebbd454d4423643ce80e2a9ac94fa54ca49f")
+	for _, testBitLen := range testVectEntropyBitLenValid {
+		expEntropy := expHash[:testBitLen / 8]
+
+		// Hash truncated to bit length, also with a reader returning one byte per call
+		entropy, err := EntropyFromReaderHashed(iotest.OneByteReader(strings.NewReader("abc")), testBitLen, false)
+		if err != nil {
+			t.Errorf("Entropy from hashed reader (%d) returned error: %s", testBitLen, err.Error())
+		} else if !bytes.Equal(entropy, expEntropy) {
+			t.Errorf("Entropy from hashed reader (%d) was incorrect: expected %x, got: %x", testBitLen, expEntropy, entropy)
+		}
+
+		// Hash mixed with random bytes (all ones, i.e. hash complement)
+		SetRandReader(bytes.NewReader(bytes.Repeat([]byte { 0xff }, testBitLen / 8)))
+		entropy, err = EntropyFromReaderHashed(strings.NewReader("abc"), testBitLen, true)
+		if err != nil {
+			t.Errorf("Entropy from mixed hashed reader (%d) returned error: %s", testBitLen, err.Error())
+		} else {
+			for i := range entropy {
+				if entropy[i] != ^expEntropy[i] {
+					t.Errorf("Entropy from mixed hashed reader (%d) was incorrect: got: %x", testBitLen, entropy)
+					break
+				}
+			}
+		}
+	}
+
+	// Random source error
+	SetRandReader(bytes.NewReader(nil))
+	if entropy, err := EntropyFromReaderHashed(strings.NewReader("abc"), EntropyBits128, true); entropy != nil || err == nil {
+		t.Errorf("Entropy from mixed hashed reader with failing random source returned wrong error (%v)", err)
+	}
+	SetRandReader(nil)
+	// Reader error
+	if _, err := EntropyFromReaderHashed(iotest.TimeoutReader(iotest.OneByteReader(strings.NewReader("abc"))), EntropyBits128, false); !errors.Is(err, iotest.ErrTimeout) {
+		t.Errorf("Entropy from failing hashed reader returned wrong error (%v)", err)
+	}
+	// Invalid bit length
+	for _, testBitLen := range testVectEntropyBitLenInvalid {
+		if _, err := EntropyFromReaderHashed(strings.NewReader("abc"), testBitLen, false); !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Entropy from hashed reader with invalid bit length (%d) returned wrong error (%v)", testBitLen, err)
+		}
+	}
+}
+
+// Test internal entropy buffers pool
+func TestEntropyBufferPool(t *testing.T) {
+	for _, byteLen := range []int { EntropyBits128 / 8, EntropyBits256 / 8, 5, 3 * EntropyBits256 / 8, entropyBatchPoolMaxLen + 1 } {
+		// Buffer shall have the requested length and be zero
+		buff := getEntropyBuffer(byteLen)
+		if len(*buff) != byteLen || !bytes.Equal(*buff, make([]byte, byteLen)) {
+			t.Errorf("Entropy buffer with length %d was incorrect: got: %x", byteLen, *buff)
+		}
+
+		// Buffer shall be wiped when put back
+		entropy := *buff
+		for i := range entropy {
+			entropy[i] = 0xff
+		}
+		putEntropyBuffer(buff)
+		if !bytes.Equal(entropy, make([]byte, byteLen)) {
+			t.Errorf("Entropy buffer with length %d put back was not wiped", byteLen)
+		}
+
+		// Next buffer shall be zero also if reused
+		buff = getEntropyBuffer(byteLen)
+		if len(*buff) != byteLen || !bytes.Equal(*buff, make([]byte, byteLen)) {
+			t.Errorf("Entropy buffer with length %d after put was incorrect: got: %x", byteLen, *buff)
+		}
+		putEntropyBuffer(buff)
+	}
+
+}
+
+// Test entropy returned to the pool
+func TestPutEntropy(t *testing.T) {
+	for _, testBitLen := range testVectEntropyBitLenValid {
+		entropy, err := GenerateEntropy(testBitLen)
+		if err != nil {
+			t.Errorf("Entropy from valid bit length (%d) returned error: %s", testBitLen, err.Error())
+			continue
+		}
+
+		// Entropy shall be wiped when returned
+		PutEntropy(entropy)
+		if !bytes.Equal(entropy, make([]byte, testBitLen / 8)) {
+			t.Errorf("Entropy returned to the pool was not wiped")
+		}
+
+		// Generation shall still work after returning entropy, also if the buffer is reused
+		entropy, err = GenerateEntropy(testBitLen)
+		if err != nil {
+			t.Errorf("Entropy from valid bit length (%d) after put returned error: %s", testBitLen, err.Error())
+		} else if len(entropy) * 8 != testBitLen || bytes.Equal(entropy, make([]byte, testBitLen / 8)) {
+			t.Errorf("Entropy from valid bit length after put was incorrect: got: %x", entropy)
+		}
+	}
+
+	// Buffers with any other length shall only be wiped
+	for _, testByteLen := range []int { 0, 5, 64 } {
+		entropy := bytes.Repeat([]byte { 0xff }, testByteLen)
+		PutEntropy(entropy)
+		if !bytes.Equal(entropy, make([]byte, testByteLen)) {
+			t.Errorf("Entropy with length %d returned to the pool was not wiped", testByteLen)
+		}
+	}
+
+	// Slices of larger buffers shall be wiped without touching the rest of the buffer
+	buff := bytes.Repeat([]byte { 0xff }, EntropyBits256 / 8)
+	PutEntropy(buff[:EntropyBits128 / 8])
+	if !bytes.Equal(buff[:EntropyBits128 / 8], make([]byte, EntropyBits128 / 8)) ||
+	   !bytes.Equal(buff[EntropyBits128 / 8:], bytes.Repeat([]byte { 0xff }, (EntropyBits256 - EntropyBits128) / 8)) {
+		t.Errorf("Entropy slice returned to the pool was not wiped correctly")
+	}
+}
+
+// Test entropy XOR split and combination
+func TestEntropyXOR(t *testing.T) {
+	for _, currTest := range testVect {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+
+		partA, partB, err := SplitEntropyXOR(entropy)
+		if err != nil {
+			t.Errorf("Entropy %s XOR split returned error: %s", currTest.Entropy, err.Error())
+			continue
+		}
+		if len(partA) != len(entropy) || len(partB) != len(entropy) {
+			t.Errorf("Entropy XOR parts lengths were incorrect: expected %d, got: %d and %d", len(entropy), len(partA), len(partB))
+			continue
+		}
+
+		// Each part shall be encodable as a mnemonic
+		for _, part := range [][]byte { partA, partB } {
+			if _, err := MnemonicFromEntropy(part); err != nil {
+				t.Errorf("Mnemonic from entropy XOR part returned error: %s", err.Error())
+			}
+		}
+
+		// Parts shall be combined back to entropy, in any order
+		for _, parts := range [][2][]byte { { partA, partB }, { partB, partA } } {
+			combined, err := CombineEntropyXOR(parts[0], parts[1])
+			if err != nil {
+				t.Errorf("Entropy XOR combination returned error: %s", err.Error())
+			} else if !bytes.Equal(combined, entropy) {
+				t.Errorf("Entropy XOR combination was incorrect: expected %s, got: %x", currTest.Entropy, combined)
+			}
+		}
+	}
+
+	// Combination of known parts
+	combined, _ := CombineEntropyXOR(bytes.Repeat([]byte { 0x0f }, 16), bytes.Repeat([]byte { 0xf0 }, 16))
+	if !bytes.Equal(combined, bytes.Repeat([]byte { 0xff }, 16)) {
+		t.Errorf("Entropy XOR combination was incorrect: expected %x, got: %x", bytes.Repeat([]byte { 0xff }, 16), combined)
+	}
+
+	// Invalid entropy length
+	for _, testByteLen := range []int { 0, 15, 17, 33 } {
+		if _, _, err := SplitEntropyXOR(make([]byte, testByteLen)); !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Entropy XOR split with invalid length (%d) returned wrong error (%v)", testByteLen, err)
+		}
+		if _, err := CombineEntropyXOR(make([]byte, testByteLen), make([]byte, testByteLen)); !errors.Is(err, ErrEntropyBitLen) {
+			t.Errorf("Entropy XOR combination with invalid length (%d) returned wrong error (%v)", testByteLen, err)
+		}
+	}
+	// Different parts lengths
+	if _, err := CombineEntropyXOR(make([]byte, 16), make([]byte, 32)); !errors.Is(err, ErrEntropyPartsLen) {
+		t.Errorf("Entropy XOR combination with different lengths returned wrong error (%v)", err)
+	}
+}
+
+// Test invalid mnemonics
+func TestMnemonicInvalid(t *testing.T) {
+	for _, testEntry := range testVectMnemonicInvalid {
+		// Create mnemonic from string
+		mnemonic := MnemonicFromString(testEntry.Mnemonic)
+		// Validate mnemonic, shall return error
+		err := mnemonic.Validate()
+		if !errors.Is(err, testEntry.Err) {
+			t.Errorf("Invalid mnemonic '%s' validation returned wrong error (%s)", testEntry.Mnemonic, err.Error())
+		}
+
+		// Get entropy back from mnemonic
+		entropy, err := mnemonic.ToEntropy()
+		// Generated entropy shall be nil and error shall be not nil
+		if entropy != nil {
+			t.Errorf("Entropy from invalid mnemonic (%s) was not nil", testEntry.Mnemonic)
+		}
+		if !errors.Is(err, testEntry.Err) {
+			t.Errorf("Entropy from invalid mnemonic (%s) returned wrong error (%s)", testEntry.Mnemonic, err.Error())
+		}
+
+		// Generate seed from mnemonic
+		seed, err := mnemonic.GenerateSeed(testPassphrase)
+		// Generated seed shall be nil and error shall be not nil
+		if seed != nil {
+			t.Errorf("Seed from invalid mnemonic (%s) was not nil", testEntry.Mnemonic)
+		}
+		if !errors.Is(err, testEntry.Err) {
+			t.Errorf("Seed from invalid mnemonic (%s) returned wrong error (%s)", testEntry.Mnemonic, err.Error())
+		}
+
+		// Generate seed reader from mnemonic, shall return error
+		seedReader, err := mnemonic.GenerateSeedReader(testPassphrase)
+		if seedReader != nil || !errors.Is(err, testEntry.Err) {
+			t.Errorf("Seed reader from invalid mnemonic (%s) returned wrong error (%v)", testEntry.Mnemonic, err)
+		}
+	}
+}
+
+// Test that errors are wrapped with context
+func TestErrorContext(t *testing.T) {
+	// Invalid word shall report the word and its position
+	err := MnemonicFromString(testVectMnemonicInvalid[2].Mnemonic).Validate()
+	if !errors.Is(err, ErrInvalidWord) || !strings.Contains(err.Error(), "\"notexistent\" at position 3") {
+		t.Errorf("Invalid word error has no context: %v", err)
+	}
+	// Invalid words number shall report the number
+	err = MnemonicFromString(testVectMnemonicInvalid[0].Mnemonic).Validate()
+	if !errors.Is(err, ErrWordsNum) || !strings.Contains(err.Error(), "11") {
+		t.Errorf("Invalid words number error has no context: %v", err)
+	}
+	// Invalid entropy bit length shall report the bit length and the valid ones
+	_, err = GenerateEntropy(127)
+	if !errors.Is(err, ErrEntropyBitLen) || !strings.Contains(err.Error(), "got 127, expected 128/160/192/224/256") {
+		t.Errorf("Invalid entropy bit length error has no context: %v", err)
+	}
+	_, err = MnemonicFromEntropy(make([]byte, 17))
+	if !errors.Is(err, ErrEntropyBitLen) || !strings.Contains(err.Error(), "got 136, expected 128/160/192/224/256") {
+		t.Errorf("Invalid entropy length error has no context: %v", err)
+	}
+}
+
+// Test invalid binary strings
+// Valid strings are implicitly tested in the test vector
+func TestBinaryStringInvalid(t *testing.T) {
+	for _, testBinStr := range testBinaryStringInvalid {
+		// Convert binary string to bytes
+		slice, err := binaryStringToBytes(testBinStr)
+		// Byte slice shall be nil and error shall be not nil
+		if slice != nil {
+			t.Errorf("Invalid binary string (%s) conversion byte slice was not nil", testBinStr)
+		}
+		if !errors.Is(err, ErrBinaryString) {
+			t.Errorf("Invalid binary string (%s) conversion returned wrong error (%v)", testBinStr, err)
+		}
+	}
+}
+
+// Test mnemonic normalization
+func TestMnemonicNormalize(t *testing.T) {
+	for _, testEntry := range testVectMnemonicNormalize {
+		// Normalize mnemonic
+		normMnemonic := NormalizeMnemonic(testEntry.Mnemonic)
+		if normMnemonic != testEntry.Normalized {
+			t.Errorf("Mnemonic '%s' normalization was incorrect: expected '%s', got: '%s'", testEntry.Mnemonic, testEntry.Normalized, normMnemonic)
+		}
+
+		// Create mnemonic from string, it shall be normalized and valid
+		mnemonic := MnemonicFromString(testEntry.Mnemonic)
+		if mnemonic.Words != testEntry.Normalized {
+			t.Errorf("Mnemonic from string was incorrect: expected '%s', got: '%s'", testEntry.Normalized, mnemonic.Words)
+		}
+		if err := mnemonic.Validate(); err != nil {
+			t.Errorf("Mnemonic '%s' validation returned error: %s", testEntry.Mnemonic, err.Error())
+		}
+
+		// Create mnemonic from raw string, it shall be kept as it is
+		mnemonic = MnemonicFromStringRaw(testEntry.Mnemonic)
+		if mnemonic.Words != testEntry.Mnemonic {
+			t.Errorf("Mnemonic from raw string was incorrect: expected '%s', got: '%s'", testEntry.Mnemonic, mnemonic.Words)
+		}
+	}
+}
+
+// Test custom words list
+func TestCustomWordsList(t *testing.T) {
+	// Build a custom words list by reversing the English one, so it's not sorted
+	wordsList := make([]string, len(wordsListEn))
+	for i, word := range wordsListEn {
+		wordsList[len(wordsListEn) - 1 - i] = word
+	}
+
+	for _, currTest := range testVect {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+
+		// Create mnemonic from entropy with custom words list
+		mnemonic, err := MnemonicFromEntropyCustom(entropy, wordsList)
+		if err != nil {
+			t.Errorf("Mnemonic from entropy %s with custom words list returned error: %s", currTest.Entropy, err.Error())
+			continue
+		}
+		// Words shall be the reversed ones
+		enMnemonic, _ := MnemonicFromEntropy(entropy)
+		for i, word := range strings.Split(mnemonic.Words, " ") {
+			enWord := strings.Split(enMnemonic.Words, " ")[i]
+			if wordsList[languageWordsListMap[LangEnglish].wordIndex(enWord)] != word {
+				t.Errorf("Mnemonic from entropy with custom words list was incorrect at word %d: got %s", i, word)
+			}
+		}
+
+		// Get entropy back
+		gotEntropy, err := mnemonic.ToEntropyCustom(wordsList)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' to entropy with custom words list returned error: %s", mnemonic.Words, err.Error())
+		} else if !bytes.Equal(gotEntropy, entropy) {
+			t.Errorf("Mnemonic '%s' to entropy with custom words list was incorrect: expected %s, got: %x", mnemonic.Words, currTest.Entropy, gotEntropy)
+		}
+	}
+
+	// Invalid words list length
+	entropy, _ := hex.DecodeString(testVect[0].Entropy)
+	if _, err := MnemonicFromEntropyCustom(entropy, wordsList[1:]); !errors.Is(err, ErrInvalidWordlistLen) {
+		t.Errorf("Mnemonic from entropy with invalid custom words list returned wrong error (%v)", err)
+	}
+	if _, err := MnemonicFromString(testVect[0].Mnemonic).ToEntropyCustom(append(wordsList, "abandon")); !errors.Is(err, ErrInvalidWordlistLen) {
+		t.Errorf("Mnemonic to entropy with invalid custom words list returned wrong error (%v)", err)
+	}
+	// Duplicate words, which would not round-trip
+	dupWordsList := append([]string {}, wordsList...)
+	dupWordsList[len(dupWordsList) - 1] = dupWordsList[0]
+	if _, err := MnemonicFromEntropyCustom(entropy, dupWordsList); !errors.Is(err, ErrDuplicateWord) {
+		t.Errorf("Mnemonic from entropy with duplicate words in custom words list returned wrong error (%v)", err)
+	}
+	if _, err := MnemonicFromString(testVect[0].Mnemonic).ToEntropyCustom(dupWordsList); !errors.Is(err, ErrDuplicateWord) ||
+	   !strings.Contains(err.Error(), "indexes 0 and 2047") {
+		t.Errorf("Mnemonic to entropy with duplicate words in custom words list returned wrong error (%v)", err)
+	}
+	// Invalid entropy length
+	if _, err := MnemonicFromEntropyCustom(entropy[1:], wordsList); !errors.Is(err, ErrEntropyBitLen) {
+		t.Errorf("Mnemonic from invalid entropy with custom words list returned wrong error (%v)", err)
+	}
+	// Invalid mnemonics
+	for _, testEntry := range testVectMnemonicInvalid {
+		if _, err := MnemonicFromString(testEntry.Mnemonic).ToEntropyCustom(wordsListEn); !errors.Is(err, testEntry.Err) {
+			t.Errorf("Invalid mnemonic '%s' to entropy with custom words list returned wrong error (%v)", testEntry.Mnemonic, err)
+		}
+	}
+}
+
+// Test JSON encoding and decoding
+func TestMnemonicJSON(t *testing.T) {
+	for _, testStr := range testVectMnemonicJSON {
+		// Encode mnemonic
+		data, err := json.Marshal(MnemonicFromStringRaw(testStr))
+		if err != nil {
+			t.Errorf("Mnemonic '%s' JSON encoding returned error: %s", testStr, err.Error())
+			continue
+		}
+
+		// Decode mnemonic, it shall be built like MnemonicFromString
+		var mnemonic Mnemonic
+		expMnemonic := MnemonicFromString(testStr)
+		err = json.Unmarshal(data, &mnemonic)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' JSON decoding returned error: %s", testStr, err.Error())
+		} else if mnemonic.Words != expMnemonic.Words || mnemonic.Language() != expMnemonic.Language() {
+			t.Errorf("Mnemonic JSON round-trip was incorrect: expected %s (%s), got: %s (%s)",
+			         expMnemonic.Words, expMnemonic.Language(), mnemonic.Words, mnemonic.Language())
+		}
+	}
+
+	// Round-trip of mnemonics of all languages shall keep them valid
+	// Ambiguous mnemonics are skipped, since their language cannot be detected
+	for _, currTest := range testVectLang {
+		if isAmbiguousMnemonic(currTest.Mnemonic) {
+			continue
+		}
+		data, err := json.Marshal(MnemonicFromStringLang(currTest.Mnemonic, currTest.Lang))
+		if err != nil {
+			t.Errorf("Mnemonic '%s' JSON encoding returned error: %s", currTest.Mnemonic, err.Error())
+			continue
+		}
+		var mnemonic Mnemonic
+		err = json.Unmarshal(data, &mnemonic)
+		if err != nil {
+			t.Errorf("Mnemonic '%s' JSON decoding returned error: %s", currTest.Mnemonic, err.Error())
+		} else if mnemonic.Language() != currTest.Lang {
+			t.Errorf("Mnemonic '%s' JSON round-trip language was incorrect: expected %s, got: %s", currTest.Mnemonic, currTest.Lang, mnemonic.Language())
+		} else if err = mnemonic.Validate(); err != nil {
+			t.Errorf("Mnemonic '%s' after JSON round-trip returned error: %s", currTest.Mnemonic, err.Error())
+		}
+	}
+
+	// Decoding shall normalize the words
+	var mnemonic Mnemonic
+	if err := json.Unmarshal([]byte("\"  Legal winner thank year wave sausage worth useful legal winner thank YELLOW \""), &mnemonic); err != nil || mnemonic.Validate() != nil {
+		t.Errorf("Mnemonic JSON decoding was not normalized: got %s (%v)", mnemonic.Words, err)
+	}
+
+	// Mnemonic shall be encoded as a plain string
+	data, _ := json.Marshal(MnemonicFromString(testVect[0].Mnemonic))
+	if string(data) != "\"" + testVect[0].Mnemonic + "\"" {
+		t.Errorf("Mnemonic JSON encoding was incorrect: got %s", string(data))
+	}
+
+	// Decoding a non-string shall fail
+	if err := json.Unmarshal([]byte("12"), &mnemonic); err == nil {
+		t.Errorf("Mnemonic JSON decoding of a non-string returned no error")
+	}
+}
+
+// Test mnemonic redaction
+func TestMnemonicRedact(t *testing.T) {
+	for _, testEntry := range testVectMnemonicRedact {
+		mnemonic := MnemonicFromStringRaw(testEntry.Mnemonic)
+		if redacted := mnemonic.Redact(testEntry.ShowFirst, testEntry.ShowLast); redacted != testEntry.Redacted {
+			t.Errorf("Mnemonic '%s' redaction (%d, %d) was incorrect: expected %s, got: %s",
+			         testEntry.Mnemonic, testEntry.ShowFirst, testEntry.ShowLast, testEntry.Redacted, redacted)
+		}
+		// Mnemonic shall not be modified
+		if mnemonic.Words != testEntry.Mnemonic {
+			t.Errorf("Mnemonic '%s' was modified by redaction: got: %s", testEntry.Mnemonic, mnemonic.Words)
+		}
+	}
+}
+
+// Test mnemonic numbered words
+func TestMnemonicNumberedWords(t *testing.T) {
+	// 24-word mnemonic
+	testMnemonic := testVect[len(testVect) - 1].Mnemonic
+	words := strings.Fields(testMnemonic)
+	numberedWords := MnemonicFromString(testMnemonic).NumberedWords()
+	if len(numberedWords) != len(words) {
+		t.Errorf("Mnemonic '%s' numbered words length was incorrect: expected %d, got: %d", testMnemonic, len(words), len(numberedWords))
+	}
+	for i := 0; i < len(words) && i < len(numberedWords); i++ {
+		expWord := fmt.Sprintf("%d. %s", i + 1, words[i])
+		if numberedWords[i] != expWord {
+			t.Errorf("Mnemonic '%s' numbered word was incorrect: expected %s, got: %s", testMnemonic, expWord, numberedWords[i])
+		}
+	}
+	if numberedWords[0] != "1. " + words[0] || numberedWords[23] != "24. " + words[23] {
+		t.Errorf("Mnemonic '%s' numbered words were not 1-based", testMnemonic)
+	}
+
+	// Any whitespace is a separator and an empty mnemonic has no words
+	numberedWords = MnemonicFromStringRaw(" abandon\tability\u3000able\n").NumberedWords()
+	if strings.Join(numberedWords, ",") != "1. abandon,2. ability,3. able" {
+		t.Errorf("Mnemonic numbered words were incorrect: got: %v", numberedWords)
+	}
+	if numberedWords = MnemonicFromStringRaw("").NumberedWords(); len(numberedWords) != 0 {
+		t.Errorf("Empty mnemonic numbered words were not empty: got: %v", numberedWords)
+	}
+}
+
+// Test mnemonic words grid
+func TestMnemonicGrid(t *testing.T) {
+	// 24-word mnemonic, with columns dividing the words number or not
+	testMnemonic := testVect[len(testVect) - 1].Mnemonic
+	words := strings.Fields(testMnemonic)
+	for _, columns := range []int { 1, 4, 5, 6, 7, 24, 30 } {
+		grid := MnemonicFromString(testMnemonic).Grid(columns)
+		expRowsNum := (len(words) + columns - 1) / columns
+		if len(grid) != expRowsNum {
+			t.Errorf("Mnemonic '%s' grid rows (%d columns) were incorrect: expected %d, got: %d", testMnemonic, columns, expRowsNum, len(grid))
+			continue
+		}
+		for i, row := range grid {
+			if len(row) != columns {
+				t.Errorf("Mnemonic '%s' grid row %d length was incorrect: expected %d, got: %d", testMnemonic, i, columns, len(row))
+				continue
+			}
+			for j, word := range row {
+				expWord := ""
+				if wordIdx := i * columns + j; wordIdx < len(words) {
+					expWord = words[wordIdx]
+				}
+				if word != expWord {
+					t.Errorf("Mnemonic '%s' grid word (%d, %d) was incorrect: expected %s, got: %s", testMnemonic, i, j, expWord, word)
+				}
+			}
+		}
+	}
+
+	// Last row shall be padded
+	grid := MnemonicFromStringRaw("abandon ability able about absent").Grid(2)
+	if fmt.Sprintf("%q", grid) != `[["abandon" "ability"] ["able" "about"] ["absent" ""]]` {
+		t.Errorf("Mnemonic grid was incorrect: got: %q", grid)
+	}
+
+	// Empty mnemonic has no rows and columns shall be positive
+	if grid = MnemonicFromStringRaw("").Grid(4); len(grid) != 0 {
+		t.Errorf("Empty mnemonic grid was not empty: got: %v", grid)
+	}
+	for _, columns := range []int { 0, -1 } {
+		if grid = MnemonicFromString(testMnemonic).Grid(columns); grid != nil {
+			t.Errorf("Mnemonic grid with invalid columns (%d) was not nil", columns)
+		}
+	}
+}
+
+// Test mnemonic comparison ignoring normal form
+func TestMnemonicEqualNormalized(t *testing.T) {
+	for _, testEntry := range testVectMnemonicEqual {
+		mnemonic1 := MnemonicFromStringRaw(testEntry.Mnemonic1)
+		mnemonic2 := MnemonicFromStringRaw(testEntry.Mnemonic2)
+		if mnemonic1.EqualNormalized(mnemonic2) != testEntry.Equal || mnemonic2.EqualNormalized(mnemonic1) != testEntry.Equal {
+			t.Errorf("Mnemonic '%s' and '%s' normalized comparison was incorrect: expected %t", testEntry.Mnemonic1, testEntry.Mnemonic2, testEntry.Equal)
+		}
+	}
+
+	// Composed (NFC) and decomposed (NFKD) forms shall be equal
+	for _, currTest := range testVectLang {
+		mnemonic := MnemonicFromStringRaw(currTest.Mnemonic)
+		nfcMnemonic := MnemonicFromStringRaw(" " + norm.NFC.String(currTest.Mnemonic) + "\n")
+		if !mnemonic.EqualNormalized(nfcMnemonic) || !nfcMnemonic.EqualNormalized(mnemonic) {
+			t.Errorf("Mnemonic '%s' and its NFC form normalized comparison was incorrect: expected true", currTest.Mnemonic)
+		}
+	}
+	// Different words in another normal form
+	if MnemonicFromStringRaw("ábaco ábaco").EqualNormalized(MnemonicFromStringRaw(norm.NFD.String("ábaco abaco"))) {
+		t.Errorf("Different mnemonics normalized comparison was incorrect: expected false")
+	}
+}
+
+// Test mnemonic clone
+func TestMnemonicClone(t *testing.T) {
+	for _, currTest := range testVect {
+		mnemonic := MnemonicFromString(currTest.Mnemonic)
+		clone := mnemonic.Clone()
+		if clone == mnemonic || !clone.Equal(mnemonic) || clone.lang != mnemonic.lang {
+			t.Errorf("Mnemonic '%s' clone was incorrect: got: %s", currTest.Mnemonic, clone.Words)
+		}
+
+		// Modifying the clone shall not modify the original
+		clone.Words = "notexistent words"
+		if mnemonic.Words != currTest.Mnemonic {
+			t.Errorf("Mnemonic '%s' was modified by its clone: got: %s", currTest.Mnemonic, mnemonic.Words)
+		}
+	}
+}
+
+// Test mnemonic comparison
+func TestMnemonicEqual(t *testing.T) {
+	for _, testEntry := range testVectMnemonicEqual {
+		mnemonic1 := MnemonicFromStringRaw(testEntry.Mnemonic1)
+		mnemonic2 := MnemonicFromStringRaw(testEntry.Mnemonic2)
+
+		// Comparison shall be symmetric
+		if mnemonic1.Equal(mnemonic2) != testEntry.Equal || mnemonic2.Equal(mnemonic1) != testEntry.Equal {
+			t.Errorf("Mnemonic '%s' and '%s' comparison was incorrect: expected %t", testEntry.Mnemonic1, testEntry.Mnemonic2, testEntry.Equal)
+		}
+	}
+}
+
+// Test wipe
+func TestWipe(t *testing.T) {
+	for _, currTest := range testVect {
+		slice, _ := hex.DecodeString(currTest.Seed)
+		Wipe(slice)
+
+		// Length shall be kept and all bytes shall be zero
+		if !bytes.Equal(slice, make([]byte, len(currTest.Seed) / 2)) {
+			t.Errorf("Wiped slice was not zero: %s", hex.EncodeToString(slice))
+		}
+	}
+}
+
+// Test that the bit arithmetic conversions are equivalent to the binary string ones
+func TestWordIndexesBinaryString(t *testing.T) {
+	for _, currTest := range testVect {
+		entropy, _ := hex.DecodeString(currTest.Entropy)
+		bitLen := (len(entropy) * 8 / 32) * 33
+
+		// Compute word indexes from binary string
+		binStr := bytesToBinaryString(entropy) + strings.Repeat("0", bitLen - (len(entropy) * 8))
+		wordsIdx := bytesToWordIndexes(append(entropy, 0), bitLen)
+		for i, wordIdx := range wordsIdx {
+			expIdx, _ := strconv.ParseInt(binStr[i * WordBitLen: (i + 1) * WordBitLen], 2, 16)
+			if int64(wordIdx) != expIdx {
+				t.Errorf("Word index %d of entropy %s was incorrect: expected %d, got: %d", i, currTest.Entropy, expIdx, wordIdx)
+			}
+		}
+
+		// Convert word indexes back to bytes
+		slice := wordIndexesToBytes(wordsIdx)
+		if !bytes.Equal(slice[:len(entropy)], entropy) {
+			t.Errorf("Word indexes to bytes was incorrect: expected %s, got: %s", currTest.Entropy, hex.EncodeToString(slice[:len(entropy)]))
+		}
+	}
+}
+
+// Benchmark mnemonic generation from entropy
+func BenchmarkMnemonicFromEntropy(b *testing.B) {
+	for _, bitLen := range testVectEntropyBitLenValid {
+		entropy, _ := benchmarkMnemonic(bitLen).ToEntropy()
+		b.Run(benchmarkName(bitLen), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				MnemonicFromEntropy(entropy)
+			}
+		})
+	}
+}
+
+// Benchmark mnemonic to entropy
+func BenchmarkToEntropy(b *testing.B) {
+	for _, bitLen := range testVectEntropyBitLenValid {
+		mnemonic := benchmarkMnemonic(bitLen)
+		b.Run(benchmarkName(bitLen), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				mnemonic.ToEntropy()
+			}
+		})
+	}
+}
+
+// Benchmark seed generation
+func BenchmarkGenerateSeed(b *testing.B) {
+	for _, bitLen := range testVectEntropyBitLenValid {
+		mnemonic := benchmarkMnemonic(bitLen)
+		b.Run(benchmarkName(bitLen), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				mnemonic.GenerateSeed(testPassphrase)
+			}
+		})
+	}
+}
+
+// Benchmark mnemonic validation
+func BenchmarkValidate(b *testing.B) {
+	for _, bitLen := range testVectEntropyBitLenValid {
+		mnemonic := benchmarkMnemonic(bitLen)
+		b.Run(benchmarkName(bitLen), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				mnemonic.Validate()
+			}
+		})
+	}
+}
+
+// Benchmark batch validation
+func BenchmarkValidateBatch(b *testing.B) {
+	mnemonics := make([]string, len(testVect))
+	for i, currTest := range testVect {
+		mnemonics[i] = currTest.Mnemonic
+	}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ValidateBatch(mnemonics, LangEnglish)
+	}
+}
+
+// Benchmark validation of the same mnemonics of BenchmarkValidateBatch one at a time
+func BenchmarkValidateLoop(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, currTest := range testVect {
+			MnemonicFromString(currTest.Mnemonic).Validate()
+		}
+	}
+}
+
+// Benchmark entropy generation
+func BenchmarkGenerateEntropy(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GenerateEntropy(EntropyBits256)
+	}
+}
+
+// Benchmark entropy generation, returning the entropy to the pool
+func BenchmarkGenerateEntropyPut(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		entropy, _ := GenerateEntropy(EntropyBits256)
+		PutEntropy(entropy)
+	}
+}
+
+// Benchmark generation of multiple mnemonics, reading entropy at once
+func BenchmarkGenerateMnemonics(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		GenerateMnemonics(100, WordsNum24, LangEnglish)
+	}
+}
+
+// Benchmark generation of the same mnemonics of BenchmarkGenerateMnemonics one at a time
+func BenchmarkGenerateMnemonicsLoop(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 100; j++ {
+			MnemonicFromWordsNumLang(WordsNum24, LangEnglish)
+		}
+	}
+}
+
+// Benchmark mnemonic to entropy into buffer
+func BenchmarkToEntropyInto(b *testing.B) {
+	mnemonic := MnemonicFromString(testVect[len(testVect) - 1].Mnemonic)
+	buff := make([]byte, EntropyBits256 / 8)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		mnemonic.ToEntropyInto(buff)
+	}
+}
+
+// Get a deterministic mnemonic with the specified entropy bit length, used for benchmarking all the mnemonic sizes
+func benchmarkMnemonic(bitLen int) *Mnemonic {
+	mnemonic, _ := MnemonicFromDeterministicSeed("benchmark", bitLen)
+	return mnemonic
+}
+
+// Get the benchmark name for the specified entropy bit length
+func benchmarkName(bitLen int) string {
+	return fmt.Sprintf("Words%d", entropyBitLenToWordsNum(bitLen))
 }
@@ -28,11 +28,23 @@ package bip39
 // Imports
 //
 import (
-	"bytes"
+	"crypto/sha256"
 	"errors"
 	"fmt"
-	"sort"
+	"math/big"
 	"strconv"
+	"strings"
+	"golang.org/x/text/unicode/norm"
+)
+
+//
+// Constants
+//
+const (
+	// Base58 alphabet (Bitcoin)
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	// Base58Check checksum length in bytes
+	base58CheckSumLen = 4
 )
 
 //
@@ -43,26 +55,41 @@ var (
 	ErrBinaryString = errors.New("The specified binary string is not valid")
 )
 
+//
+// Exported functions
+//
+
+// Wipe the specified byte slice by overwriting it with zeros.
+// It can be used for removing secrets (e.g. entropy or seed) from memory as soon as they are not needed anymore.
+// Note that the Go garbage collector may have already copied the data elsewhere, so this only reduces the exposure window.
+func Wipe(slice []byte) {
+	for i := range slice {
+		slice[i] = 0
+	}
+}
+
 //
 // Not-exported functions
 //
 
 // Convert the specified byte slice to a binary string.
 func bytesToBinaryString(slice []byte) string {
-	// Convert each byte to its bits representation as string
-	var strBuff bytes.Buffer
-	for _, b := range(slice) {
-		strBuff.WriteString(fmt.Sprintf("%.8b", b))
+	// Convert each bit to its character, most significant bit first
+	binStr := make([]byte, len(slice) * 8)
+	for i, b := range(slice) {
+		for j := 0; j < 8; j++ {
+			binStr[(i * 8) + j] = '0' + ((b >> uint(7 - j)) & 1)
+		}
 	}
 
-	return strBuff.String()
+	return string(binStr)
 }
 
 // Convert the specified binary string to a byte slice.
 func binaryStringToBytes(binStr string) ([]byte, error) {
 	// Length of the binary string shall be multiple of 8
 	if (len(binStr) % 8) != 0 {
-		return nil, ErrBinaryString
+		return nil, fmt.Errorf("binary string length %d: %w", len(binStr), ErrBinaryString)
 	}
 
 	// Create slice
@@ -72,10 +99,11 @@ func binaryStringToBytes(binStr string) ([]byte, error) {
 	for i := 0; i < len(binStr); i += 8 {
 		// Convert current byte
 		byteStrBin := binStr[i: i + 8]
-		byteVal, err := strconv.ParseInt(byteStrBin, 2, 16)
+		// ParseUint is used since it doesn't accept a sign, so only '0' and '1' characters are valid
+		byteVal, err := strconv.ParseUint(byteStrBin, 2, 8)
 		// Stop if conversion error
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("binary string byte %q: %w", byteStrBin, ErrBinaryString)
 		}
 		// Append new byte
 		slice = append(slice, byte(byteVal))
@@ -84,15 +112,101 @@ func binaryStringToBytes(binStr string) ([]byte, error) {
 	return slice, nil
 }
 
-// Perform binary search to find a string in a slice, by returning its index.
-// If not found, -1 will be returned.
-// The algorithm is simply implemented by using the sort library.
-func stringBinarySearch(slice []string, elem string) int {
-	idx := sort.SearchStrings(slice, elem)
+// Convert the first bitLen bits of the specified byte slice to 11-bit word indexes.
+// The bit length shall be a multiple of 11 and not exceed the slice length.
+func bytesToWordIndexes(slice []byte, bitLen int) []int {
+	wordsIdx := make([]int, 0, bitLen / WordBitLen)
+
+	// Shift bytes into an accumulator and extract 11 bits each time they are available
+	var acc uint32
+	accBitLen := 0
+	for i := 0; len(wordsIdx) < cap(wordsIdx); i++ {
+		acc = (acc << 8) | uint32(slice[i])
+		accBitLen += 8
+
+		if accBitLen >= WordBitLen {
+			accBitLen -= WordBitLen
+			wordsIdx = append(wordsIdx, int((acc >> uint(accBitLen)) & wordBitMask))
+		}
+	}
+
+	return wordsIdx
+}
+
+// Convert the specified 11-bit word indexes to a byte slice.
+// If the total bit length is not a multiple of 8, the last byte is padded with zeros in the least significant bits.
+func wordIndexesToBytes(wordsIdx []int) []byte {
+	bitLen := len(wordsIdx) * WordBitLen
+	slice := make([]byte, 0, (bitLen + 7) / 8)
+
+	// Shift indexes into an accumulator and extract 8 bits each time they are available
+	var acc uint32
+	accBitLen := 0
+	for _, wordIdx := range wordsIdx {
+		acc = (acc << WordBitLen) | (uint32(wordIdx) & wordBitMask)
+		accBitLen += WordBitLen
+
+		for accBitLen >= 8 {
+			accBitLen -= 8
+			slice = append(slice, byte(acc >> uint(accBitLen)))
+		}
+	}
+	// Append remaining bits
+	if accBitLen > 0 {
+		slice = append(slice, byte(acc << uint(8 - accBitLen)))
+	}
+
+	return slice
+}
+
+// Normalize the specified string in NFKD form.
+func normalizeNfkd(str string) string {
+	return norm.NFKD.String(str)
+}
+
+// Remove the combining characters (i.e. the accents, if NFKD-normalized) from the specified string.
+func removeCombiningChars(str string) string {
+	return strings.Map(func(r rune) rune {
+		if norm.NFKD.PropertiesString(string(r)).CCC() != 0 {
+			return -1
+		}
+		return r
+	}, str)
+}
+
+// Collapse the whitespaces of the specified string into single spaces, removing leading and trailing ones.
+func collapseWhitespaces(str string) string {
+	return strings.Join(strings.Fields(str), " ")
+}
+
+// Encode the specified bytes in Base58Check, i.e. Base58 of the bytes followed by the first 4 bytes of their double SHA-256.
+func base58CheckEncode(slice []byte) string {
+	// Append checksum
+	hash := sha256.Sum256(slice)
+	hash = sha256.Sum256(hash[:])
+	data := append(append(make([]byte, 0, len(slice) + base58CheckSumLen), slice...), hash[:base58CheckSumLen]...)
+	defer Wipe(data)
+
+	// Convert to base 58, least significant digit first
+	val := new(big.Int).SetBytes(data)
+	base := big.NewInt(int64(len(base58Alphabet)))
+	digit := new(big.Int)
+	var encoded []byte
+	for val.Sign() > 0 {
+		val.DivMod(val, base, digit)
+		encoded = append(encoded, base58Alphabet[digit.Int64()])
+	}
+	// Each leading zero byte is encoded as the first alphabet character
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
 
-	if idx != len(slice) && slice[idx] == elem {
-		return idx
-	} else {
-		return -1
+	// Reverse digits
+	for i, j := 0, len(encoded) - 1; i < j; i, j = i + 1, j - 1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
 	}
+	return string(encoded)
 }
@@ -19,7 +19,7 @@
 // THE SOFTWARE.
 
 //
-// This file cointains the words list (only English for now) for bip39 package.
+// This file cointains the English words list for bip39 package.
 //
 
 package bip39
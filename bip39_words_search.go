@@ -0,0 +1,287 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//
+// This file contains words search functions (e.g. suggestions, completion) for bip39 package.
+//
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"crypto/subtle"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+//
+// Constants
+//
+const (
+	// Maximum edit distance for word suggestions
+	suggestMaxDistance = 2
+	// Number of letters that uniquely identify a word
+	uniquePrefixLen = 4
+	// Maximum edit distance for repairing a mnemonic word
+	repairMaxDistance = 1
+)
+
+//
+// Exported functions
+//
+
+// Suggest the words of the specified language that are similar to the specified word.
+// The words with edit (Levenshtein) distance 1 are returned or, if none, the ones with distance 2.
+// It can be used for suggesting corrections when a word is not valid.
+// If the word is valid, it's returned as the only suggestion. If the language is not supported, nil is returned.
+func SuggestWord(word string, lang Language) []string {
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return nil
+	}
+
+	// Group words by distance
+	word = normalizeNfkd(strings.ToLower(word))
+	suggestions := make([][]string, suggestMaxDistance + 1)
+	for _, currWord := range wordsList.words {
+		dist := levenshteinDistance(word, currWord, suggestMaxDistance)
+		if dist <= suggestMaxDistance {
+			suggestions[dist] = append(suggestions[dist], currWord)
+		}
+	}
+
+	// Return the group with the lowest distance
+	for _, currSuggestions := range suggestions {
+		if len(currSuggestions) != 0 {
+			return currSuggestions
+		}
+	}
+	return nil
+}
+
+// Get all the words of the specified language starting with the specified prefix, sorted lexicographically.
+// The prefix and the words are compared normalized (NFKD, lowercase) and without accents, so the accents
+// can be omitted (e.g. "aba" is completed to the Spanish "ábaco"). If the language is not supported, nil is returned.
+func CompletePrefix(prefix string, lang Language) []string {
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return nil
+	}
+
+	// Collect the words with the same prefix
+	prefix = searchNormalize(prefix)
+	var words []string
+	for _, word := range wordsList.sorted() {
+		if strings.HasPrefix(searchNormalize(word), prefix) {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// Get the word of the specified language identified by the specified prefix, normalized as in CompletePrefix.
+// BIP-0039 words are uniquely identified by their first 4 letters, so the prefix shall be at least 4 letters long.
+// Chinese words are single characters, so for Chinese languages the prefix shall be exactly the word.
+// If the prefix does not identify exactly one word (or the language is not supported), false is returned.
+func UniqueWord(prefix string, lang Language) (string, bool) {
+	prefix = searchNormalize(prefix)
+
+	words := CompletePrefix(prefix, lang)
+	if len(words) != 1 {
+		return "", false
+	}
+	if utf8.RuneCountInString(prefix) < uniquePrefixLen && !(isChineseLanguage(lang) && words[0] == prefix) {
+		return "", false
+	}
+	return words[0], true
+}
+
+// Get if the specified word belongs to the words list of any supported language, and the first language containing it.
+// The languages are checked by value (i.e. as returned by SupportedLanguages), so for words belonging to more languages
+// the one with the lowest value is returned (e.g. English for "abandon", which is also French).
+// The word is NFKD-normalized before searching it, so both composed and decomposed forms are accepted.
+// If the word is not found, false is returned together with zero.
+func IsWordValid(word string) (bool, Language) {
+	for _, lang := range SupportedLanguages() {
+		if languageWordsListMap[lang].wordIndex(word) != -1 {
+			return true, lang
+		}
+	}
+	return false, 0
+}
+
+// Get the index of the specified word in the words list of the specified language, -1 if not found (or the language is not supported).
+// Differently from the lookup used by the other functions, the whole words list is always scanned and compared in constant time,
+// so the time doesn't depend on the word position (only on the word length). It's slower, so use it only in high-security environments.
+// The word is NFKD-normalized before searching it, so both composed and decomposed forms are accepted.
+func WordIndexConstantTime(word string, lang Language) int {
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return -1
+	}
+
+	// Compare with all words without early exit, accumulating the index of the matching one
+	wordBytes := []byte(normalizeNfkd(word))
+	wordIdx := 0
+	found := 0
+	for i, currWord := range wordsList.words {
+		equal := subtle.ConstantTimeCompare([]byte(currWord), wordBytes)
+		wordIdx |= subtle.ConstantTimeSelect(equal, i, 0)
+		found |= equal
+	}
+
+	return subtle.ConstantTimeSelect(found, wordIdx, -1)
+}
+
+// Try to repair a mnemonic containing a single mistake, using the words list of the specified language.
+// If exactly one word is not valid, it's replaced with each word at edit (Levenshtein) distance 1 from it.
+// If all words are valid but the checksum is not, each pair of adjacent words is swapped.
+// All the resulting valid mnemonics are returned (none if the mistake cannot be repaired).
+// If the mnemonic is already valid, it's returned as the only result.
+// Error is returned if words number or language is not valid, or if more than one word is not valid.
+func (mnemonic *Mnemonic) Repair(lang Language) ([]*Mnemonic, error) {
+	// Get words list
+	wordsList, err := getWordsList(lang)
+	if err != nil {
+		return nil, err
+	}
+	// Validate words number
+	words := mnemonic.WordSlice()
+	err = validateWordsNum(len(words))
+	if err != nil {
+		return nil, err
+	}
+
+	// Find invalid words
+	invalidIdx := -1
+	for i, word := range words {
+		if wordsList.wordIndex(word) == -1 {
+			if invalidIdx != -1 {
+				return nil, fmt.Errorf("more than one invalid word (positions %d and %d): %w", invalidIdx, i, ErrInvalidWord)
+			}
+			invalidIdx = i
+		}
+	}
+
+	// Collect the valid mnemonics among the candidates
+	var repaired []*Mnemonic
+	addIfValid := func(candidate []string) {
+		candidateMnemonic := MnemonicFromStringLang(strings.Join(candidate, wordsSeparator), lang)
+		if candidateMnemonic.IsValid() {
+			repaired = append(repaired, candidateMnemonic)
+		}
+	}
+
+	candidate := make([]string, len(words))
+	copy(candidate, words)
+	if invalidIdx != -1 {
+		// Replace the invalid word with the similar ones
+		invalidWord := normalizeNfkd(strings.ToLower(words[invalidIdx]))
+		for _, currWord := range wordsList.words {
+			if levenshteinDistance(invalidWord, currWord, repairMaxDistance) <= repairMaxDistance {
+				candidate[invalidIdx] = currWord
+				addIfValid(candidate)
+			}
+		}
+	} else {
+		// Already valid
+		addIfValid(candidate)
+		if len(repaired) != 0 {
+			return repaired, nil
+		}
+		// Swap adjacent words
+		for i := 0; i < len(candidate) - 1; i++ {
+			if candidate[i] == candidate[i + 1] {
+				continue
+			}
+			candidate[i], candidate[i + 1] = candidate[i + 1], candidate[i]
+			addIfValid(candidate)
+			candidate[i], candidate[i + 1] = candidate[i + 1], candidate[i]
+		}
+	}
+
+	return repaired, nil
+}
+
+//
+// Not-exported functions
+//
+
+// Normalize the specified prefix or word for searching, i.e. NFKD-normalized and lowercase without accents.
+func searchNormalize(str string) string {
+	return removeCombiningChars(strings.ToLower(normalizeNfkd(str)))
+}
+
+// Get if the specified language is a Chinese one.
+func isChineseLanguage(lang Language) bool {
+	return lang == LangChineseSimplified || lang == LangChineseTraditional
+}
+
+// Compute the Levenshtein distance between two strings.
+// The computation is stopped as soon as the distance is known to exceed maxDist, in that case maxDist + 1 is returned.
+func levenshteinDistance(str1 string, str2 string, maxDist int) int {
+	runes1, runes2 := []rune(str1), []rune(str2)
+
+	// Length difference is a lower bound of the distance
+	lenDiff := len(runes1) - len(runes2)
+	if lenDiff > maxDist || -lenDiff > maxDist {
+		return maxDist + 1
+	}
+
+	// Compute distance keeping only the previous row of the matrix
+	prevRow := make([]int, len(runes2) + 1)
+	currRow := make([]int, len(runes2) + 1)
+	for j := range prevRow {
+		prevRow[j] = j
+	}
+
+	for i := 1; i <= len(runes1); i++ {
+		currRow[0] = i
+		rowMin := currRow[0]
+
+		for j := 1; j <= len(runes2); j++ {
+			cost := 1
+			if runes1[i - 1] == runes2[j - 1] {
+				cost = 0
+			}
+			currRow[j] = minInt(minInt(prevRow[j] + 1, currRow[j - 1] + 1), prevRow[j - 1] + cost)
+			rowMin = minInt(rowMin, currRow[j])
+		}
+		// Stop if the distance cannot be lower than maxDist anymore
+		if rowMin > maxDist {
+			return maxDist + 1
+		}
+
+		prevRow, currRow = currRow, prevRow
+	}
+
+	return minInt(prevRow[len(runes2)], maxDist + 1)
+}
+
+// Get the minimum between two integers.
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
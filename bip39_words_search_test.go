@@ -0,0 +1,442 @@
+// Copyright (c) 2020 Emanuele Bellocchia
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package bip39
+
+//
+// Imports
+//
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"golang.org/x/text/unicode/norm"
+)
+
+//
+// Types
+//
+
+// Word suggestion test vector entry structure
+type testVectSuggestWordEntry struct {
+	Word        string
+	Suggestions []string
+}
+
+// Prefix completion test vector entry structure
+type testVectCompletePrefixEntry struct {
+	Prefix     string
+	Words      []string
+	UniqueWord string
+}
+
+// Prefix completion test vector entry structure for languages
+type testVectCompletePrefixLangEntry struct {
+	Lang       Language
+	Prefix     string
+	Words      []string
+	UniqueWord string
+}
+
+// Mnemonic repair test vector entry structure
+type testVectRepairEntry struct {
+	Mnemonic string
+	Repaired []string
+}
+
+//
+// Variables
+//
+
+// Tests for word suggestion (English)
+var testVectSuggestWord = []testVectSuggestWordEntry {
+	// Valid word
+	testVectSuggestWordEntry {
+		Word:        "abandon",
+		Suggestions: []string { "abandon" },
+	},
+	// Distance 1
+	testVectSuggestWordEntry {
+		Word:        "abandn",
+		Suggestions: []string { "abandon" },
+	},
+	testVectSuggestWordEntry {
+		Word:        "Zooo",
+		Suggestions: []string { "zoo" },
+	},
+	testVectSuggestWordEntry {
+		Word:        "ca",
+		Suggestions: []string { "can", "car", "cat" },
+	},
+	// Distance 2
+	testVectSuggestWordEntry {
+		Word:        "abxndxn",
+		Suggestions: []string { "abandon" },
+	},
+	// No suggestions
+	testVectSuggestWordEntry {
+		Word:        "qwertyuiop",
+		Suggestions: nil,
+	},
+}
+
+// Tests for prefix completion (English)
+var testVectCompletePrefix = []testVectCompletePrefixEntry {
+	testVectCompletePrefixEntry {
+		Prefix:     "aban",
+		Words:      []string { "abandon" },
+		UniqueWord: "abandon",
+	},
+	testVectCompletePrefixEntry {
+		Prefix:     "ZOO",
+		Words:      []string { "zoo" },
+		UniqueWord: "",
+	},
+	testVectCompletePrefixEntry {
+		Prefix:     "act",
+		Words:      []string { "act", "action", "actor", "actress", "actual" },
+		UniqueWord: "",
+	},
+	testVectCompletePrefixEntry {
+		Prefix:     "actr",
+		Words:      []string { "actress" },
+		UniqueWord: "actress",
+	},
+	testVectCompletePrefixEntry {
+		Prefix:     "actress",
+		Words:      []string { "actress" },
+		UniqueWord: "actress",
+	},
+	testVectCompletePrefixEntry {
+		Prefix:     "zz",
+		Words:      nil,
+		UniqueWord: "",
+	},
+	testVectCompletePrefixEntry {
+		Prefix:     "actresses",
+		Words:      nil,
+		UniqueWord: "",
+	},
+}
+
+// Tests for prefix completion (other languages, accents can be omitted)
+var testVectCompletePrefixLang = []testVectCompletePrefixLangEntry {
+	testVectCompletePrefixLangEntry {
+		Lang:       LangSpanish,
+		Prefix:     "aba",
+		Words:      []string { "ábaco" },
+		UniqueWord: "",
+	},
+	testVectCompletePrefixLangEntry {
+		Lang:       LangSpanish,
+		Prefix:     "ABAC",
+		Words:      []string { "ábaco" },
+		UniqueWord: "ábaco",
+	},
+	testVectCompletePrefixLangEntry {
+		Lang:       LangSpanish,
+		Prefix:     "ábac",
+		Words:      []string { "ábaco" },
+		UniqueWord: "ábaco",
+	},
+	testVectCompletePrefixLangEntry {
+		Lang:       LangFrench,
+		Prefix:     "ecr",
+		Words:      []string { "écraser", "écrémer", "écrivain", "écrou" },
+		UniqueWord: "",
+	},
+	testVectCompletePrefixLangEntry {
+		Lang:       LangFrench,
+		Prefix:     "ecri",
+		Words:      []string { "écrivain" },
+		UniqueWord: "écrivain",
+	},
+	testVectCompletePrefixLangEntry {
+		Lang:       LangFrench,
+		Prefix:     "Écrou",
+		Words:      []string { "écrou" },
+		UniqueWord: "écrou",
+	},
+	testVectCompletePrefixLangEntry {
+		Lang:       LangChineseSimplified,
+		Prefix:     "的",
+		Words:      []string { "的" },
+		UniqueWord: "的",
+	},
+	testVectCompletePrefixLangEntry {
+		Lang:       LangChineseTraditional,
+		Prefix:     "的",
+		Words:      []string { "的" },
+		UniqueWord: "的",
+	},
+	testVectCompletePrefixLangEntry {
+		Lang:       LangChineseSimplified,
+		Prefix:     "的的",
+		Words:      nil,
+		UniqueWord: "",
+	},
+}
+
+// Tests for mnemonic repair (English)
+var testVectRepair = []testVectRepairEntry {
+	// Already valid
+	testVectRepairEntry {
+		Mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+		Repaired: []string { "legal winner thank year wave sausage worth useful legal winner thank yellow" },
+	},
+	// Misspelled word
+	testVectRepairEntry {
+		Mnemonic: "legal winner thnk year wave sausage worth useful legal winner thank yellow",
+		Repaired: []string { "legal winner thank year wave sausage worth useful legal winner thank yellow" },
+	},
+	testVectRepairEntry {
+		Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abot",
+		Repaired: []string { "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about" },
+	},
+	// Swapped words
+	testVectRepairEntry {
+		Mnemonic: "legal winner thank year wave sausage worth useful legal winner yellow thank",
+		Repaired: []string { "legal winner thank year wave sausage worth useful legal winner thank yellow" },
+	},
+	// Not repairable
+	testVectRepairEntry {
+		Mnemonic: "legal winner thank year wave sausage worth useful legal winner thank qwerty",
+		Repaired: nil,
+	},
+}
+
+//
+// Functions
+//
+
+// Test word suggestion
+func TestSuggestWord(t *testing.T) {
+	for _, testEntry := range testVectSuggestWord {
+		suggestions := SuggestWord(testEntry.Word, LangEnglish)
+		if !reflect.DeepEqual(suggestions, testEntry.Suggestions) {
+			t.Errorf("Word '%s' suggestions were incorrect: expected %v, got: %v", testEntry.Word, testEntry.Suggestions, suggestions)
+		}
+	}
+
+	// Unsupported language
+	if SuggestWord("abandon", Language(-1)) != nil {
+		t.Errorf("Word suggestions for unsupported language were not nil")
+	}
+}
+
+// Test Levenshtein distance
+func TestLevenshteinDistance(t *testing.T) {
+	if dist := levenshteinDistance("kitten", "sitting", 5); dist != 3 {
+		t.Errorf("Levenshtein distance was incorrect: expected 3, got: %d", dist)
+	}
+	if dist := levenshteinDistance("kitten", "sitting", 2); dist != 3 {
+		t.Errorf("Levenshtein distance with maximum was incorrect: expected 3, got: %d", dist)
+	}
+	if dist := levenshteinDistance("", "abc", 5); dist != 3 {
+		t.Errorf("Levenshtein distance from empty string was incorrect: expected 3, got: %d", dist)
+	}
+	if dist := levenshteinDistance("ábaco", "abaco", 5); dist != 1 {
+		t.Errorf("Levenshtein distance of accented strings was incorrect: expected 1, got: %d", dist)
+	}
+}
+
+// Test prefix completion
+func TestCompletePrefix(t *testing.T) {
+	for _, testEntry := range testVectCompletePrefix {
+		// Complete prefix
+		words := CompletePrefix(testEntry.Prefix, LangEnglish)
+		if !reflect.DeepEqual(words, testEntry.Words) {
+			t.Errorf("Prefix '%s' completion was incorrect: expected %v, got: %v", testEntry.Prefix, testEntry.Words, words)
+		}
+
+		// Get unique word
+		word, ok := UniqueWord(testEntry.Prefix, LangEnglish)
+		if word != testEntry.UniqueWord || ok != (testEntry.UniqueWord != "") {
+			t.Errorf("Prefix '%s' unique word was incorrect: expected '%s', got: '%s' (%t)", testEntry.Prefix, testEntry.UniqueWord, word, ok)
+		}
+	}
+
+	// Other languages (words lists are NFKD-normalized)
+	for _, testEntry := range testVectCompletePrefixLang {
+		var expWords []string
+		for _, word := range testEntry.Words {
+			expWords = append(expWords, normalizeNfkd(word))
+		}
+		expUniqueWord := normalizeNfkd(testEntry.UniqueWord)
+
+		words := CompletePrefix(testEntry.Prefix, testEntry.Lang)
+		if !reflect.DeepEqual(words, expWords) {
+			t.Errorf("Prefix '%s' completion (%s) was incorrect: expected %v, got: %v", testEntry.Prefix, testEntry.Lang, expWords, words)
+		}
+
+		word, ok := UniqueWord(testEntry.Prefix, testEntry.Lang)
+		if word != expUniqueWord || ok != (expUniqueWord != "") {
+			t.Errorf("Prefix '%s' unique word (%s) was incorrect: expected '%s', got: '%s' (%t)", testEntry.Prefix, testEntry.Lang, expUniqueWord, word, ok)
+		}
+	}
+
+	// Empty prefix shall return all words
+	if words := CompletePrefix("", LangEnglish); len(words) != len(wordsListEn) {
+		t.Errorf("Empty prefix completion was incorrect: expected %d words, got: %d", len(wordsListEn), len(words))
+	}
+
+	// Every word shall be identified by its first 4 letters
+	for _, word := range wordsListEn {
+		prefix := word
+		if len(prefix) > uniquePrefixLen {
+			prefix = prefix[:uniquePrefixLen]
+		}
+		if len(prefix) == uniquePrefixLen {
+			if uniqueWord, ok := UniqueWord(prefix, LangEnglish); !ok || uniqueWord != word {
+				t.Errorf("Word '%s' is not identified by prefix '%s'", word, prefix)
+			}
+		}
+	}
+
+	// Unsupported language
+	if CompletePrefix("aban", Language(-1)) != nil {
+		t.Errorf("Prefix completion for unsupported language was not nil")
+	}
+	if _, ok := UniqueWord("aban", Language(-1)); ok {
+		t.Errorf("Unique word for unsupported language was found")
+	}
+}
+
+// Test constant-time word index
+func TestWordIndexConstantTime(t *testing.T) {
+	for _, lang := range SupportedLanguages() {
+		wordsList, _ := getWordsList(lang)
+		for i, word := range wordsList.words {
+			if wordIdx := WordIndexConstantTime(word, lang); wordIdx != i {
+				t.Errorf("Word '%s' constant-time index was incorrect: expected %d, got: %d", word, i, wordIdx)
+			}
+		}
+	}
+
+	// Composed form shall be accepted
+	if wordIdx := WordIndexConstantTime("ábaco", LangSpanish); wordIdx != 0 {
+		t.Errorf("Word 'ábaco' constant-time index was incorrect: expected 0, got: %d", wordIdx)
+	}
+	// Not existent words
+	for _, word := range []string { "", "abando", "abandonn", "ABANDON" } {
+		if wordIdx := WordIndexConstantTime(word, LangEnglish); wordIdx != -1 {
+			t.Errorf("Not existent word '%s' constant-time index was incorrect: expected -1, got: %d", word, wordIdx)
+		}
+	}
+	// Unsupported language
+	if wordIdx := WordIndexConstantTime("abandon", Language(-1)); wordIdx != -1 {
+		t.Errorf("Word constant-time index for unsupported language was incorrect: expected -1, got: %d", wordIdx)
+	}
+}
+
+// Test word validity in any language
+func TestIsWordValid(t *testing.T) {
+	// Words of a single language
+	for _, testLang := range SupportedLanguages() {
+		wordsList, _ := getWordsList(testLang)
+		for _, word := range []string { wordsList.words[0], wordsList.words[wordsListLen / 2], wordsList.words[wordsListLen - 1] } {
+			// The word can belong also to a language with a lower value
+			valid, lang := IsWordValid(word)
+			if !valid {
+				t.Errorf("Word '%s' of language %s was not valid", word, testLang)
+			} else if lang > testLang || languageWordsListMap[lang].wordIndex(word) == -1 {
+				t.Errorf("Word '%s' language was incorrect: expected %s, got: %s", word, testLang, lang)
+			}
+		}
+	}
+
+	// Words of more languages, the lowest language value shall be returned
+	testWords := map[string]Language {
+		"abandon": LangEnglish,
+		"animal":  LangEnglish,
+		"的":      LangChineseSimplified,
+		"这":      LangChineseSimplified,
+		"這":      LangChineseTraditional,
+	}
+	for testWord, expLang := range testWords {
+		if valid, lang := IsWordValid(testWord); !valid || lang != expLang {
+			t.Errorf("Word '%s' validity was incorrect: expected %s, got: %t %s", testWord, expLang, valid, lang)
+		}
+	}
+
+	// Composed and decomposed forms
+	for _, testWord := range []string { norm.NFC.String("académie"), norm.NFD.String("académie") } {
+		if valid, lang := IsWordValid(testWord); !valid || lang != LangFrench {
+			t.Errorf("Word '%s' validity was incorrect: expected %s, got: %t %s", testWord, LangFrench, valid, lang)
+		}
+	}
+
+	// Invalid words
+	for _, testWord := range []string { "", "abandom", "ABANDON", "abandon ", "zzzz" } {
+		if valid, lang := IsWordValid(testWord); valid || lang != 0 {
+			t.Errorf("Invalid word '%s' was valid: got: %s", testWord, lang)
+		}
+	}
+}
+
+// Test mnemonic repair
+func TestRepair(t *testing.T) {
+	for _, testEntry := range testVectRepair {
+		repaired, err := MnemonicFromString(testEntry.Mnemonic).Repair(LangEnglish)
+		if err != nil {
+			t.Errorf("Repair returned error: %s", err)
+			continue
+		}
+
+		var repairedWords []string
+		for _, mnemonic := range repaired {
+			repairedWords = append(repairedWords, mnemonic.Words)
+		}
+		if !reflect.DeepEqual(repairedWords, testEntry.Repaired) {
+			t.Errorf("Mnemonic '%s' repair was incorrect: expected %v, got: %v", testEntry.Mnemonic, testEntry.Repaired, repairedWords)
+		}
+	}
+
+	// More than one invalid word
+	_, err := MnemonicFromString("legal winnr thnk year wave sausage worth useful legal winner thank yellow").Repair(LangEnglish)
+	if !errors.Is(err, ErrInvalidWord) {
+		t.Errorf("Repair with more than one invalid word returned wrong error (%v)", err)
+	}
+	// Invalid words number
+	_, err = MnemonicFromString("legal winner thank year").Repair(LangEnglish)
+	if !errors.Is(err, ErrWordsNum) {
+		t.Errorf("Repair with invalid words number returned wrong error (%v)", err)
+	}
+	// Unsupported language
+	_, err = MnemonicFromString("legal winner thank year wave sausage worth useful legal winner thank yellow").Repair(Language(-1))
+	if !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("Repair with unsupported language returned wrong error (%v)", err)
+	}
+}
+
+// Benchmark word index lookup
+func BenchmarkWordIndex(b *testing.B) {
+	wordsList, _ := getWordsList(LangEnglish)
+	for i := 0; i < b.N; i++ {
+		wordsList.wordIndex("zoo")
+	}
+}
+
+// Benchmark constant-time word index lookup
+func BenchmarkWordIndexConstantTime(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		WordIndexConstantTime("zoo", LangEnglish)
+	}
+}
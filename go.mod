@@ -2,4 +2,7 @@ module github.com/ebellocchia/go-bip39
 
 go 1.14
 
-require golang.org/x/crypto v0.0.0-20200420201142-3c4aac89819a
+require (
+	golang.org/x/crypto v0.0.0-20200420201142-3c4aac89819a
+	golang.org/x/text v0.3.3
+)